@@ -9,6 +9,16 @@ import (
 )
 
 func TestCompleteEventTypes(t *testing.T) {
+	allTypes := ValidEventTypeNames()
+
+	// Every type except player_join, prefixed with "player_join,"
+	var remaining []string
+	for _, name := range allTypes {
+		if name != "player_join" {
+			remaining = append(remaining, "player_join,"+name)
+		}
+	}
+
 	tests := []struct {
 		name       string
 		toComplete string
@@ -19,7 +29,7 @@ func TestCompleteEventTypes(t *testing.T) {
 			name:       "empty input returns all types",
 			toComplete: "",
 			flagVals:   nil,
-			want:       []string{"player_join", "player_left", "world_join"},
+			want:       allTypes,
 		},
 		{
 			name:       "prefix pla filters to player types",
@@ -34,14 +44,14 @@ func TestCompleteEventTypes(t *testing.T) {
 			want:       []string{"player_join"},
 		},
 		{
-			name:       "prefix wo filters to world_join",
-			toComplete: "wo",
+			name:       "prefix world_j filters to world_join",
+			toComplete: "world_j",
 			flagVals:   nil,
 			want:       []string{"world_join"},
 		},
 		{
 			name:       "comma prefix preserves already typed values",
-			toComplete: "player_join,wo",
+			toComplete: "player_join,world_j",
 			flagVals:   nil,
 			want:       []string{"player_join,world_join"},
 		},
@@ -55,7 +65,7 @@ func TestCompleteEventTypes(t *testing.T) {
 			name:       "empty after comma returns remaining types",
 			toComplete: "player_join,",
 			flagVals:   nil,
-			want:       []string{"player_join,player_left", "player_join,world_join"},
+			want:       remaining,
 		},
 		{
 			name:       "excludes values from flag",
@@ -83,7 +93,7 @@ func TestCompleteEventTypes(t *testing.T) {
 		},
 		{
 			name:       "all types used returns empty",
-			toComplete: "player_join,player_left,world_join,",
+			toComplete: strings.Join(allTypes, ",") + ",",
 			flagVals:   nil,
 			want:       nil,
 		},
@@ -3,6 +3,7 @@ package main
 import (
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/vrclog/vrclog-go/pkg/vrclog"
 	"github.com/vrclog/vrclog-go/pkg/vrclog/event"
 )
@@ -24,7 +25,7 @@ func TestValidEventTypeNames(t *testing.T) {
 	}
 
 	// Should contain all expected names
-	expected := []string{"player_join", "player_left", "world_join"}
+	expected := []string{"app_quit", "app_start", "asset_download", "avatar_change", "disconnect", "emoji_spawn", "group_instance_join", "group_notification", "moderation", "notification", "player_join", "player_left", "portal_drop", "print_place", "remote_download", "screenshot", "self_authenticated", "sticker_spawn", "udon_exception", "unrecognized", "video_play", "world_join", "world_leave"}
 	for _, name := range expected {
 		found := false
 		for _, n := range names {
@@ -136,6 +137,26 @@ func TestNormalizeEventTypes(t *testing.T) {
 	}
 }
 
+func TestNormalizeEventTypes_RegisteredType(t *testing.T) {
+	custom := vrclog.MustRegisterEventType("cli_test_custom")
+
+	got, err := NormalizeEventTypes([]string{"CLI_TEST_CUSTOM"})
+	if err != nil {
+		t.Fatalf("NormalizeEventTypes() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != custom {
+		t.Errorf("NormalizeEventTypes() = %v, want [%v]", got, custom)
+	}
+
+	// Shell completion offers registered types as well
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringSlice("include-types", nil, "")
+	candidates, _ := completeEventTypes("include-types")(cmd, nil, "cli_")
+	if len(candidates) != 1 || candidates[0] != "cli_test_custom" {
+		t.Errorf("completeEventTypes() = %v, want [cli_test_custom]", candidates)
+	}
+}
+
 func TestRejectOverlap(t *testing.T) {
 	tests := []struct {
 		name     string
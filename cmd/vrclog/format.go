@@ -4,14 +4,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/vrclog/vrclog-go/pkg/vrclog"
 )
 
 // ValidFormats lists all valid output formats.
 var ValidFormats = map[string]bool{
-	"jsonl":  true,
-	"pretty": true,
+	"jsonl":       true,
+	"jsonl-typed": true,
+	"pretty":      true,
 }
 
 // OutputEvent writes an event in the specified format to the writer.
@@ -19,6 +21,8 @@ func OutputEvent(format string, event vrclog.Event, out io.Writer) error {
 	switch format {
 	case "jsonl":
 		return OutputJSON(event, out)
+	case "jsonl-typed":
+		return OutputTypedJSON(event, out)
 	case "pretty":
 		return OutputPretty(event, out)
 	default:
@@ -36,21 +40,124 @@ func OutputJSON(event vrclog.Event, out io.Writer) error {
 	return err
 }
 
+// OutputTypedJSON writes an event as JSON Lines in its typed form, with the
+// type-specific fields under "data" (see vrclog.TypedEvent).
+func OutputTypedJSON(event vrclog.Event, out io.Writer) error {
+	data, err := json.Marshal(event.Typed())
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
 // OutputPretty writes an event in human-readable format.
 func OutputPretty(event vrclog.Event, out io.Writer) error {
 	ts := event.Timestamp.Format("15:04:05")
 
 	var err error
-	switch event.Type {
-	case vrclog.EventPlayerJoin:
-		_, err = fmt.Fprintf(out, "[%s] + %s joined\n", ts, event.PlayerName)
-	case vrclog.EventPlayerLeft:
-		_, err = fmt.Fprintf(out, "[%s] - %s left\n", ts, event.PlayerName)
-	case vrclog.EventWorldJoin:
-		if event.WorldName != "" {
-			_, err = fmt.Fprintf(out, "[%s] > Joined world: %s\n", ts, event.WorldName)
+	switch d := event.Data.(type) {
+	case vrclog.PlayerJoinData:
+		_, err = fmt.Fprintf(out, "[%s] + %s joined%s\n", ts, d.PlayerName, localSuffix(d.IsLocal))
+	case vrclog.PlayerLeftData:
+		_, err = fmt.Fprintf(out, "[%s] - %s left%s\n", ts, d.PlayerName, localSuffix(d.IsLocal))
+	case vrclog.SelfAuthenticatedData:
+		_, err = fmt.Fprintf(out, "[%s] @ Logged in as %s\n", ts, d.PlayerName)
+	case vrclog.WorldJoinData:
+		if d.WorldName != "" {
+			_, err = fmt.Fprintf(out, "[%s] > Joined world: %s\n", ts, d.WorldName)
+		} else {
+			_, err = fmt.Fprintf(out, "[%s] > Joined instance: %s\n", ts, d.InstanceID)
+		}
+	case vrclog.WorldLeaveData:
+		if d.WorldName != "" {
+			_, err = fmt.Fprintf(out, "[%s] < Left world: %s\n", ts, d.WorldName)
+		} else {
+			_, err = fmt.Fprintf(out, "[%s] < Left world\n", ts)
+		}
+	case vrclog.DisconnectData:
+		if d.Reason != "" {
+			_, err = fmt.Fprintf(out, "[%s] x Disconnected: %s\n", ts, d.Reason)
+		} else {
+			_, err = fmt.Fprintf(out, "[%s] x Disconnected\n", ts)
+		}
+	case vrclog.NotificationData:
+		if d.WorldName != "" {
+			_, err = fmt.Fprintf(out, "[%s] & %s from %s: %s\n", ts, d.NotificationType, d.PlayerName, d.WorldName)
+		} else {
+			_, err = fmt.Fprintf(out, "[%s] & %s from %s\n", ts, d.NotificationType, d.PlayerName)
+		}
+	case vrclog.UdonExceptionData:
+		msg, _, _ := strings.Cut(d.ExceptionMessage, "\n")
+		if d.ObjectName != "" {
+			_, err = fmt.Fprintf(out, "[%s] ! Udon exception in %s: %s\n", ts, d.ObjectName, msg)
+		} else {
+			_, err = fmt.Fprintf(out, "[%s] ! Udon exception: %s\n", ts, msg)
+		}
+	case vrclog.AppStartData:
+		if d.VRMode != "" {
+			_, err = fmt.Fprintf(out, "[%s] ^ VRChat started: %s (%s)\n", ts, d.BuildVersion, d.VRMode)
 		} else {
-			_, err = fmt.Fprintf(out, "[%s] > Joined instance: %s\n", ts, event.InstanceID)
+			_, err = fmt.Fprintf(out, "[%s] ^ VRChat started: %s\n", ts, d.BuildVersion)
+		}
+	case vrclog.AppQuitData:
+		_, err = fmt.Fprintf(out, "[%s] ^ VRChat quit\n", ts)
+	case vrclog.RemoteDownloadData:
+		switch d.DownloadStatus {
+		case "failed":
+			_, err = fmt.Fprintf(out, "[%s] ! %s download failed: %s (%s)\n", ts, d.DownloadKind, d.DownloadURL, d.DownloadError)
+		default:
+			_, err = fmt.Fprintf(out, "[%s] ~ %s download %s: %s\n", ts, d.DownloadKind, d.DownloadStatus, d.DownloadURL)
+		}
+	case vrclog.AssetDownloadData:
+		switch d.DownloadStatus {
+		case "failed":
+			_, err = fmt.Fprintf(out, "[%s] ! %s %s failed to load: %s\n", ts, d.DownloadKind, d.AssetID, d.DownloadError)
+		case "completed":
+			_, err = fmt.Fprintf(out, "[%s] ~ %s %s downloaded%s\n", ts, d.DownloadKind, d.AssetID, downloadDetails(d.AssetSize, d.DurationMS))
+		default:
+			_, err = fmt.Fprintf(out, "[%s] ~ %s %s download %s\n", ts, d.DownloadKind, d.AssetID, d.DownloadStatus)
+		}
+	case vrclog.UnrecognizedData:
+		_, err = fmt.Fprintf(out, "[%s] ? %s\n", ts, d.Message)
+	case vrclog.PortalDropData:
+		if d.WorldID != "" {
+			_, err = fmt.Fprintf(out, "[%s] o %s dropped a portal to %s\n", ts, d.PlayerName, d.WorldID)
+		} else {
+			_, err = fmt.Fprintf(out, "[%s] o %s dropped a portal\n", ts, d.PlayerName)
+		}
+	case vrclog.StickerSpawnData:
+		_, err = fmt.Fprintf(out, "[%s] o %s placed a sticker\n", ts, d.PlayerName)
+	case vrclog.EmojiSpawnData:
+		_, err = fmt.Fprintf(out, "[%s] o %s spawned an emoji\n", ts, d.PlayerName)
+	case vrclog.PrintPlaceData:
+		_, err = fmt.Fprintf(out, "[%s] o %s placed a print\n", ts, d.PlayerName)
+	case vrclog.ModerationData:
+		if d.PlayerName != "" {
+			_, err = fmt.Fprintf(out, "[%s] ! %s: %s (by %s)\n", ts, d.ModerationAction, d.TargetName, d.PlayerName)
+		} else {
+			_, err = fmt.Fprintf(out, "[%s] ! %s: %s\n", ts, d.ModerationAction, d.TargetName)
+		}
+	case vrclog.GroupInstanceJoinData:
+		_, err = fmt.Fprintf(out, "[%s] > Joined group instance: %s (%s)\n", ts, d.InstanceID, d.GroupID)
+	case vrclog.GroupNotificationData:
+		_, err = fmt.Fprintf(out, "[%s] & %s from %s (%s)\n", ts, d.NotificationType, d.PlayerName, d.GroupID)
+	case vrclog.AvatarChangeData:
+		_, err = fmt.Fprintf(out, "[%s] * %s changed avatar to %s\n", ts, d.PlayerName, d.AvatarName)
+	case vrclog.ScreenshotData:
+		if d.WorldName != "" {
+			_, err = fmt.Fprintf(out, "[%s] # Screenshot in %s: %s\n", ts, d.WorldName, d.ScreenshotPath)
+		} else {
+			_, err = fmt.Fprintf(out, "[%s] # Screenshot: %s\n", ts, d.ScreenshotPath)
+		}
+	case vrclog.VideoPlayData:
+		switch {
+		case d.VideoError != "":
+			_, err = fmt.Fprintf(out, "[%s] ! Video error: %s\n", ts, d.VideoError)
+		case d.ResolvedURL != "":
+			_, err = fmt.Fprintf(out, "[%s] ~ Video resolved: %s\n", ts, d.VideoURL)
+		default:
+			_, err = fmt.Fprintf(out, "[%s] ~ Video requested: %s\n", ts, d.VideoURL)
 		}
 	default:
 		_, err = fmt.Fprintf(out, "[%s] ? %s\n", ts, event.Type)
@@ -58,3 +165,27 @@ func OutputPretty(event vrclog.Event, out io.Writer) error {
 
 	return err
 }
+
+// downloadDetails formats the size and duration of a completed download
+// for pretty output, leaving out parts that were not logged.
+func downloadDetails(size, durationMS int64) string {
+	var parts []string
+	if size != 0 {
+		parts = append(parts, fmt.Sprintf("%d bytes", size))
+	}
+	if durationMS != 0 {
+		parts = append(parts, fmt.Sprintf("%dms", durationMS))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// localSuffix marks player events for the local user in pretty output.
+func localSuffix(isLocal bool) string {
+	if isLocal {
+		return " (you)"
+	}
+	return ""
+}
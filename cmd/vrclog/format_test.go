@@ -17,10 +17,12 @@ var updateGolden = flag.Bool("update-golden", false, "update golden files")
 
 func TestOutputJSON(t *testing.T) {
 	event := vrclog.Event{
-		Type:       vrclog.EventPlayerJoin,
-		Timestamp:  time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
-		PlayerName: "TestUser",
-		PlayerID:   "usr_12345",
+		Type:      vrclog.EventPlayerJoin,
+		Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+		Data: vrclog.PlayerJoinData{
+			PlayerName: "TestUser",
+			PlayerID:   "usr_12345",
+		},
 	}
 
 	var buf bytes.Buffer
@@ -35,8 +37,8 @@ func TestOutputJSON(t *testing.T) {
 		t.Fatalf("OutputJSON() produced invalid JSON: %v", err)
 	}
 
-	if decoded.PlayerName != "TestUser" {
-		t.Errorf("decoded.PlayerName = %q, want %q", decoded.PlayerName, "TestUser")
+	if d, ok := decoded.Data.(vrclog.PlayerJoinData); !ok || d.PlayerName != "TestUser" {
+		t.Errorf("decoded.Data = %+v, want PlayerJoinData for TestUser", decoded.Data)
 	}
 }
 
@@ -49,18 +51,22 @@ func TestOutputPretty(t *testing.T) {
 		{
 			name: "player_join",
 			event: vrclog.Event{
-				Type:       vrclog.EventPlayerJoin,
-				Timestamp:  time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
-				PlayerName: "TestUser",
+				Type:      vrclog.EventPlayerJoin,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.PlayerJoinData{
+					PlayerName: "TestUser",
+				},
 			},
 			contains: "+ TestUser joined",
 		},
 		{
 			name: "player_left",
 			event: vrclog.Event{
-				Type:       vrclog.EventPlayerLeft,
-				Timestamp:  time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
-				PlayerName: "TestUser",
+				Type:      vrclog.EventPlayerLeft,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.PlayerLeftData{
+					PlayerName: "TestUser",
+				},
 			},
 			contains: "- TestUser left",
 		},
@@ -69,19 +75,374 @@ func TestOutputPretty(t *testing.T) {
 			event: vrclog.Event{
 				Type:      vrclog.EventWorldJoin,
 				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
-				WorldName: "Test World",
+				Data: vrclog.WorldJoinData{
+					WorldName: "Test World",
+				},
 			},
 			contains: "> Joined world: Test World",
 		},
 		{
 			name: "world_join_instance_only",
 			event: vrclog.Event{
-				Type:       vrclog.EventWorldJoin,
-				Timestamp:  time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
-				InstanceID: "12345~private",
+				Type:      vrclog.EventWorldJoin,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.WorldJoinData{
+					InstanceID: "12345~private",
+				},
 			},
 			contains: "> Joined instance: 12345~private",
 		},
+		{
+			name: "player_join_local",
+			event: vrclog.Event{
+				Type:      vrclog.EventPlayerJoin,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.PlayerJoinData{
+					PlayerName: "TestUser",
+					IsLocal:    true,
+				},
+			},
+			contains: "+ TestUser joined (you)",
+		},
+		{
+			name: "self_authenticated",
+			event: vrclog.Event{
+				Type:      vrclog.EventSelfAuthenticated,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.SelfAuthenticatedData{
+					PlayerName: "TestUser",
+					PlayerID:   "usr_12345",
+				},
+			},
+			contains: "@ Logged in as TestUser",
+		},
+		{
+			name: "screenshot",
+			event: vrclog.Event{
+				Type:      vrclog.EventScreenshot,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.ScreenshotData{
+					WorldName:      "Test World",
+					ScreenshotPath: "VRChat_2024-01-15.png",
+				},
+			},
+			contains: "# Screenshot in Test World: VRChat_2024-01-15.png",
+		},
+		{
+			name: "screenshot_unknown_world",
+			event: vrclog.Event{
+				Type:      vrclog.EventScreenshot,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.ScreenshotData{
+					ScreenshotPath: "VRChat_2024-01-15.png",
+				},
+			},
+			contains: "# Screenshot: VRChat_2024-01-15.png",
+		},
+		{
+			name: "world_leave",
+			event: vrclog.Event{
+				Type:      vrclog.EventWorldLeave,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.WorldLeaveData{
+					WorldName: "Test World",
+				},
+			},
+			contains: "< Left world: Test World",
+		},
+		{
+			name: "disconnect",
+			event: vrclog.Event{
+				Type:      vrclog.EventDisconnect,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.DisconnectData{
+					Reason: "ClientTimeout",
+				},
+			},
+			contains: "x Disconnected: ClientTimeout",
+		},
+		{
+			name: "notification_invite",
+			event: vrclog.Event{
+				Type:      vrclog.EventNotification,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.NotificationData{
+					PlayerName:       "Sender",
+					NotificationType: "invite",
+					WorldName:        "Test World",
+				},
+			},
+			contains: "& invite from Sender: Test World",
+		},
+		{
+			name: "notification_friend_request",
+			event: vrclog.Event{
+				Type:      vrclog.EventNotification,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.NotificationData{
+					PlayerName:       "Sender",
+					NotificationType: "friendRequest",
+				},
+			},
+			contains: "& friendRequest from Sender",
+		},
+		{
+			name: "udon_exception",
+			event: vrclog.Event{
+				Type:      vrclog.EventUdonException,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.UdonExceptionData{
+					ObjectName:       "Door Switch",
+					ExceptionMessage: "first line\nsecond line",
+				},
+			},
+			contains: "! Udon exception in Door Switch: first line\n",
+		},
+		{
+			name: "app_start",
+			event: vrclog.Event{
+				Type:      vrclog.EventAppStart,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.AppStartData{
+					BuildVersion: "2024.1.1p2-1409--Release",
+					VRMode:       "desktop",
+				},
+			},
+			contains: "^ VRChat started: 2024.1.1p2-1409--Release (desktop)",
+		},
+		{
+			name: "app_quit",
+			event: vrclog.Event{
+				Type:      vrclog.EventAppQuit,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data:      vrclog.AppQuitData{},
+			},
+			contains: "^ VRChat quit",
+		},
+		{
+			name: "asset_download_completed",
+			event: vrclog.Event{
+				Type:      vrclog.EventAssetDownload,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.AssetDownloadData{
+					DownloadKind:   "world",
+					AssetID:        "wrld_1",
+					DownloadStatus: "completed",
+					AssetSize:      12845056,
+					DurationMS:     120000,
+				},
+			},
+			contains: "~ world wrld_1 downloaded (12845056 bytes, 120000ms)",
+		},
+		{
+			name: "asset_download_completed_size_only",
+			event: vrclog.Event{
+				Type:      vrclog.EventAssetDownload,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.AssetDownloadData{
+					DownloadKind:   "world",
+					AssetID:        "wrld_1",
+					DownloadStatus: "completed",
+					AssetSize:      12845056,
+				},
+			},
+			contains: "~ world wrld_1 downloaded (12845056 bytes)\n",
+		},
+		{
+			name: "asset_download_completed_no_details",
+			event: vrclog.Event{
+				Type:      vrclog.EventAssetDownload,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.AssetDownloadData{
+					DownloadKind:   "avatar",
+					AssetID:        "avtr_1",
+					DownloadStatus: "completed",
+				},
+			},
+			contains: "~ avatar avtr_1 downloaded\n",
+		},
+		{
+			name: "asset_download_failed",
+			event: vrclog.Event{
+				Type:      vrclog.EventAssetDownload,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.AssetDownloadData{
+					DownloadKind:   "avatar",
+					AssetID:        "avtr_1",
+					DownloadStatus: "failed",
+					DownloadError:  "Incompatible asset bundle",
+				},
+			},
+			contains: "! avatar avtr_1 failed to load: Incompatible asset bundle",
+		},
+		{
+			name: "unrecognized",
+			event: vrclog.Event{
+				Type:      vrclog.EventUnrecognized,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.UnrecognizedData{
+					Message: "Restored player 4",
+					Shape:   "Restored player <n>",
+				},
+			},
+			contains: "? Restored player 4",
+		},
+		{
+			name: "remote_download_requested",
+			event: vrclog.Event{
+				Type:      vrclog.EventRemoteDownload,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.RemoteDownloadData{
+					DownloadKind:   "string",
+					DownloadURL:    "https://example.com/data.json",
+					DownloadStatus: "requested",
+				},
+			},
+			contains: "~ string download requested: https://example.com/data.json",
+		},
+		{
+			name: "remote_download_failed",
+			event: vrclog.Event{
+				Type:      vrclog.EventRemoteDownload,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.RemoteDownloadData{
+					DownloadKind:   "image",
+					DownloadURL:    "https://example.com/a.png",
+					DownloadStatus: "failed",
+					DownloadError:  "Image is too large",
+				},
+			},
+			contains: "! image download failed: https://example.com/a.png (Image is too large)",
+		},
+		{
+			name: "portal_drop",
+			event: vrclog.Event{
+				Type:      vrclog.EventPortalDrop,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.PortalDropData{
+					PlayerName: "TestUser",
+					WorldID:    "wrld_12345",
+				},
+			},
+			contains: "o TestUser dropped a portal to wrld_12345",
+		},
+		{
+			name: "sticker_spawn",
+			event: vrclog.Event{
+				Type:      vrclog.EventStickerSpawn,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.StickerSpawnData{
+					PlayerName: "TestUser",
+				},
+			},
+			contains: "o TestUser placed a sticker",
+		},
+		{
+			name: "emoji_spawn",
+			event: vrclog.Event{
+				Type:      vrclog.EventEmojiSpawn,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.EmojiSpawnData{
+					PlayerName: "TestUser",
+				},
+			},
+			contains: "o TestUser spawned an emoji",
+		},
+		{
+			name: "print_place",
+			event: vrclog.Event{
+				Type:      vrclog.EventPrintPlace,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.PrintPlaceData{
+					PlayerName: "TestUser",
+				},
+			},
+			contains: "o TestUser placed a print",
+		},
+		{
+			name: "moderation",
+			event: vrclog.Event{
+				Type:      vrclog.EventModeration,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.ModerationData{
+					ModerationAction: "vote_kick",
+					TargetName:       "Bad User",
+					PlayerName:       "Mod User",
+				},
+			},
+			contains: "! vote_kick: Bad User (by Mod User)",
+		},
+		{
+			name: "group_instance_join",
+			event: vrclog.Event{
+				Type:      vrclog.EventGroupInstanceJoin,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.GroupInstanceJoinData{
+					InstanceID: "12345~group(grp_1)",
+					GroupID:    "grp_1",
+				},
+			},
+			contains: "> Joined group instance: 12345~group(grp_1) (grp_1)",
+		},
+		{
+			name: "group_notification",
+			event: vrclog.Event{
+				Type:      vrclog.EventGroupNotification,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.GroupNotificationData{
+					PlayerName:       "Group Admin",
+					NotificationType: "groupAnnouncement",
+					GroupID:          "grp_1",
+				},
+			},
+			contains: "& groupAnnouncement from Group Admin (grp_1)",
+		},
+		{
+			name: "avatar_change",
+			event: vrclog.Event{
+				Type:      vrclog.EventAvatarChange,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.AvatarChangeData{
+					PlayerName: "TestUser",
+					AvatarName: "Cool Avatar",
+				},
+			},
+			contains: "* TestUser changed avatar to Cool Avatar",
+		},
+		{
+			name: "video_play_requested",
+			event: vrclog.Event{
+				Type:      vrclog.EventVideoPlay,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.VideoPlayData{
+					VideoURL: "https://example.com/video",
+				},
+			},
+			contains: "~ Video requested: https://example.com/video",
+		},
+		{
+			name: "video_play_resolved",
+			event: vrclog.Event{
+				Type:      vrclog.EventVideoPlay,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.VideoPlayData{
+					VideoURL:    "https://example.com/video",
+					ResolvedURL: "https://cdn.example.com/video.mp4",
+				},
+			},
+			contains: "~ Video resolved: https://example.com/video",
+		},
+		{
+			name: "video_play_error",
+			event: vrclog.Event{
+				Type:      vrclog.EventVideoPlay,
+				Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+				Data: vrclog.VideoPlayData{
+					VideoError: "Video unavailable",
+				},
+			},
+			contains: "! Video error: Video unavailable",
+		},
 	}
 
 	for _, tt := range tests {
@@ -101,9 +462,11 @@ func TestOutputPretty(t *testing.T) {
 
 func TestOutputEvent(t *testing.T) {
 	event := vrclog.Event{
-		Type:       vrclog.EventPlayerJoin,
-		Timestamp:  time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
-		PlayerName: "TestUser",
+		Type:      vrclog.EventPlayerJoin,
+		Timestamp: time.Date(2024, 1, 15, 12, 30, 45, 0, time.UTC),
+		Data: vrclog.PlayerJoinData{
+			PlayerName: "TestUser",
+		},
 	}
 
 	tests := []struct {
@@ -118,6 +481,13 @@ func TestOutputEvent(t *testing.T) {
 				return strings.Contains(s, `"player_name":"TestUser"`)
 			},
 		},
+		{
+			format:  "jsonl-typed",
+			wantErr: false,
+			checkFunc: func(s string) bool {
+				return strings.Contains(s, `"data":{"player_name":"TestUser"}`)
+			},
+		},
 		{
 			format:  "pretty",
 			wantErr: false,
@@ -166,18 +536,22 @@ func TestOutputEvent_Golden(t *testing.T) {
 			name:   "pretty_player_join",
 			format: "pretty",
 			event: vrclog.Event{
-				Type:       vrclog.EventPlayerJoin,
-				Timestamp:  fixedTime,
-				PlayerName: "TestUser",
+				Type:      vrclog.EventPlayerJoin,
+				Timestamp: fixedTime,
+				Data: vrclog.PlayerJoinData{
+					PlayerName: "TestUser",
+				},
 			},
 		},
 		{
 			name:   "pretty_player_left",
 			format: "pretty",
 			event: vrclog.Event{
-				Type:       vrclog.EventPlayerLeft,
-				Timestamp:  fixedTime,
-				PlayerName: "TestUser",
+				Type:      vrclog.EventPlayerLeft,
+				Timestamp: fixedTime,
+				Data: vrclog.PlayerLeftData{
+					PlayerName: "TestUser",
+				},
 			},
 		},
 		{
@@ -186,16 +560,275 @@ func TestOutputEvent_Golden(t *testing.T) {
 			event: vrclog.Event{
 				Type:      vrclog.EventWorldJoin,
 				Timestamp: fixedTime,
-				WorldName: "Test World",
+				Data: vrclog.WorldJoinData{
+					WorldName: "Test World",
+				},
+			},
+		},
+		{
+			name:   "pretty_avatar_change",
+			format: "pretty",
+			event: vrclog.Event{
+				Type:      vrclog.EventAvatarChange,
+				Timestamp: fixedTime,
+				Data: vrclog.AvatarChangeData{
+					PlayerName: "TestUser",
+					AvatarName: "Cool Avatar",
+				},
+			},
+		},
+		{
+			name:   "jsonl_avatar_change",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventAvatarChange,
+				Timestamp: fixedTime,
+				Data: vrclog.AvatarChangeData{
+					PlayerName: "TestUser",
+					AvatarName: "Cool Avatar",
+					AvatarID:   "avtr_12345",
+				},
+			},
+		},
+		{
+			name:   "jsonl_player_join_local",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventPlayerJoin,
+				Timestamp: fixedTime,
+				Data: vrclog.PlayerJoinData{
+					PlayerName: "TestUser",
+					PlayerID:   "usr_12345",
+					IsLocal:    true,
+				},
+			},
+		},
+		{
+			name:   "pretty_world_leave",
+			format: "pretty",
+			event: vrclog.Event{
+				Type:      vrclog.EventWorldLeave,
+				Timestamp: fixedTime,
+				Data: vrclog.WorldLeaveData{
+					WorldName: "Test World",
+				},
+			},
+		},
+		{
+			name:   "jsonl_disconnect",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventDisconnect,
+				Timestamp: fixedTime,
+				Data: vrclog.DisconnectData{
+					Reason: "ClientTimeout",
+				},
+			},
+		},
+		{
+			name:   "jsonl_notification",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventNotification,
+				Timestamp: fixedTime,
+				Data: vrclog.NotificationData{
+					PlayerName:       "Sender",
+					PlayerID:         "usr_12345",
+					WorldID:          "wrld_12345",
+					WorldName:        "Test World",
+					InstanceID:       "12345~region(jp)",
+					NotificationType: "invite",
+					NotificationID:   "not_12345",
+					Details: map[string]string{
+						"worldId":   "wrld_12345:12345~region(jp)",
+						"worldName": "Test World",
+					},
+				},
+			},
+		},
+		{
+			name:   "jsonl_udon_exception",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventUdonException,
+				Timestamp: fixedTime,
+				Data: vrclog.UdonExceptionData{
+					ObjectName:       "Door Switch",
+					ExceptionMessage: "Object reference not set to an instance of an object.",
+					StackTrace:       "VRC.Udon.VM.UdonVMException: The VM encountered an error!\n  at VRC.Udon.VM.UdonVM.Interpret ()",
+				},
+			},
+		},
+		{
+			name:   "jsonl_app_start",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventAppStart,
+				Timestamp: fixedTime,
+				Data: vrclog.AppStartData{
+					BuildVersion: "2024.1.1p2-1409--Release",
+					UnityVersion: "2022.3.6f1-DWR",
+					VRMode:       "vr",
+					CommandLine:  "--profile=0",
+				},
+			},
+		},
+		{
+			name:   "jsonl_asset_download",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventAssetDownload,
+				Timestamp: fixedTime,
+				Data: vrclog.AssetDownloadData{
+					DownloadKind:   "world",
+					AssetID:        "wrld_12345",
+					DownloadStatus: "completed",
+					AssetSize:      12845056,
+					DurationMS:     120000,
+				},
+			},
+		},
+		{
+			name:   "jsonl_remote_download",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventRemoteDownload,
+				Timestamp: fixedTime,
+				Data: vrclog.RemoteDownloadData{
+					WorldID:        "wrld_12345",
+					WorldName:      "Test World",
+					InstanceID:     "12345~region(jp)",
+					DownloadKind:   "string",
+					DownloadURL:    "https://example.com/data.json",
+					DownloadStatus: "failed",
+					DownloadError:  "HTTP/1.1 404 Not Found",
+				},
+			},
+		},
+		{
+			name:   "jsonl_sticker_spawn",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventStickerSpawn,
+				Timestamp: fixedTime,
+				Data: vrclog.StickerSpawnData{
+					PlayerName: "TestUser",
+					PlayerID:   "usr_12345",
+					ItemID:     "inv_12345",
+				},
+			},
+		},
+		{
+			name:   "jsonl_moderation",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventModeration,
+				Timestamp: fixedTime,
+				Data: vrclog.ModerationData{
+					PlayerName:       "Mod User",
+					ModerationAction: "mute",
+					TargetName:       "Loud User",
+					TargetID:         "usr_12345",
+				},
+			},
+		},
+		{
+			name:   "jsonl_group_instance_join",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventGroupInstanceJoin,
+				Timestamp: fixedTime,
+				Data: vrclog.GroupInstanceJoinData{
+					WorldID:    "wrld_12345",
+					InstanceID: "12345~group(grp_12345)~groupAccessType(plus)",
+					Instance: &vrclog.InstanceInfo{
+						Name:            "12345",
+						AccessType:      vrclog.AccessGroup,
+						OwnerID:         "grp_12345",
+						GroupAccessType: "plus",
+					},
+					GroupID: "grp_12345",
+				},
+			},
+		},
+		{
+			name:   "jsonl_screenshot",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventScreenshot,
+				Timestamp: fixedTime,
+				Data: vrclog.ScreenshotData{
+					WorldID:        "wrld_12345",
+					WorldName:      "Test World",
+					InstanceID:     "12345~region(jp)",
+					ScreenshotPath: "VRChat_2024-01-15_23-59-59.png",
+				},
+			},
+		},
+		{
+			name:   "jsonl_video_play",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventVideoPlay,
+				Timestamp: fixedTime,
+				Data: vrclog.VideoPlayData{
+					VideoURL:    "https://example.com/video",
+					ResolvedURL: "https://cdn.example.com/video.mp4",
+				},
 			},
 		},
 		{
 			name:   "jsonl_player_join",
 			format: "jsonl",
 			event: vrclog.Event{
-				Type:       vrclog.EventPlayerJoin,
-				Timestamp:  fixedTime,
-				PlayerName: "TestUser",
+				Type:      vrclog.EventPlayerJoin,
+				Timestamp: fixedTime,
+				Data: vrclog.PlayerJoinData{
+					PlayerName: "TestUser",
+				},
+			},
+		},
+		{
+			name:   "jsonl_typed_world_join",
+			format: "jsonl-typed",
+			event: vrclog.Event{
+				Type:      vrclog.EventWorldJoin,
+				Timestamp: fixedTime,
+				Data: vrclog.WorldJoinData{
+					WorldID:    "wrld_12345",
+					InstanceID: "12345~region(jp)",
+					Instance: &vrclog.InstanceInfo{
+						Name:       "12345",
+						AccessType: vrclog.AccessPublic,
+						Region:     "jp",
+					},
+				},
+			},
+		},
+		{
+			name:   "jsonl_typed_app_quit",
+			format: "jsonl-typed",
+			event: vrclog.Event{
+				Type:      vrclog.EventAppQuit,
+				Timestamp: fixedTime,
+				Data:      vrclog.AppQuitData{},
+			},
+		},
+		{
+			name:   "jsonl_source",
+			format: "jsonl",
+			event: vrclog.Event{
+				Type:      vrclog.EventPlayerJoin,
+				Timestamp: fixedTime,
+				Data: vrclog.PlayerJoinData{
+					PlayerName: "TestUser",
+				},
+				Source: &vrclog.Source{
+					File:   "output_log_2024-01-15_23-00-00.txt",
+					Line:   1234,
+					Offset: 98765,
+					Seq:    42,
+				},
 			},
 		},
 	}
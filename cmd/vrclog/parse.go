@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -23,6 +25,11 @@ var (
 	parseFormat       string
 	parseRaw          bool
 	parseStopOnError  bool
+	parseTZ           string
+	parseUTC          bool
+	parseUnrecognized bool
+	parseDriftReport  bool
+	parseSource       bool
 )
 
 var parseCmd = &cobra.Command{
@@ -49,9 +56,18 @@ Examples:
   # Human-readable output
   vrclog parse --format pretty
 
+  # Parse logs copied from a machine in another time zone
+  vrclog parse --tz America/New_York --utc output_log_2024-01-15.txt
+
+  # Check for log format changes after a VRChat update
+  vrclog parse --drift-report > /dev/null
+
   # Parse specific files
   vrclog parse output_log_2024-01-15.txt output_log_2024-01-16.txt
 
+  # Locate events in the log files
+  vrclog parse --source --include-types udon_exception
+
   # Pipe to jq for filtering
   vrclog parse | jq 'select(.type == "world_join")'`,
 	RunE: runParse,
@@ -61,7 +77,7 @@ func init() {
 	parseCmd.Flags().StringVarP(&parseLogDir, "log-dir", "d", "",
 		"VRChat log directory (auto-detected if not specified)")
 	parseCmd.Flags().StringSliceVar(&parseIncludeTypes, "include-types", nil,
-		"Event types to include (comma-separated: world_join,player_join,player_left)")
+		"Event types to include (comma-separated: "+strings.Join(ValidEventTypeNames(), ",")+")")
 	parseCmd.Flags().StringSliceVar(&parseExcludeTypes, "exclude-types", nil,
 		"Event types to exclude (comma-separated)")
 	parseCmd.Flags().StringVar(&parseSince, "since", "",
@@ -69,11 +85,21 @@ func init() {
 	parseCmd.Flags().StringVar(&parseUntil, "until", "",
 		"Only events before timestamp (RFC3339 format)")
 	parseCmd.Flags().StringVarP(&parseFormat, "format", "f", "jsonl",
-		"Output format: jsonl, pretty")
+		"Output format: jsonl, jsonl-typed, pretty")
 	parseCmd.Flags().BoolVar(&parseRaw, "raw", false,
 		"Include raw log lines in output")
 	parseCmd.Flags().BoolVar(&parseStopOnError, "stop-on-error", false,
 		"Stop on first error instead of skipping")
+	parseCmd.Flags().StringVar(&parseTZ, "tz", "",
+		"Time zone of the log timestamps (IANA name, UTC or Local; default Local)")
+	parseCmd.Flags().BoolVar(&parseUTC, "utc", false,
+		"Output timestamps in UTC")
+	parseCmd.Flags().BoolVar(&parseUnrecognized, "unrecognized", false,
+		"Output unrecognized [Behaviour] lines as 'unrecognized' events")
+	parseCmd.Flags().BoolVar(&parseDriftReport, "drift-report", false,
+		"Print the most frequent unrecognized [Behaviour] line shapes to stderr when done")
+	parseCmd.Flags().BoolVar(&parseSource, "source", false,
+		"Include the source file, line, byte offset and sequence number in output")
 
 	// Register completion for event type flags
 	registerEventTypeCompletion(parseCmd, "include-types")
@@ -83,7 +109,7 @@ func init() {
 func runParse(cmd *cobra.Command, args []string) error {
 	// Validate format
 	if !ValidFormats[parseFormat] {
-		return fmt.Errorf("invalid format %q: must be one of: jsonl, pretty", parseFormat)
+		return fmt.Errorf("invalid format %q: must be one of: jsonl, jsonl-typed, pretty", parseFormat)
 	}
 
 	// Normalize and validate event types
@@ -105,6 +131,11 @@ func runParse(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	loc, err := loadLocation(parseTZ)
+	if err != nil {
+		return err
+	}
+
 	// Setup context with signal handling
 	ctx, stop := signal.NotifyContext(context.Background(),
 		syscall.SIGINT, syscall.SIGTERM)
@@ -133,12 +164,25 @@ func runParse(cmd *cobra.Command, args []string) error {
 		opts = append(opts, vrclog.WithDirTimeRange(sinceTime, untilTime))
 	}
 
+	if loc != time.Local {
+		opts = append(opts, vrclog.WithDirLocation(loc))
+	}
 	if parseRaw {
 		opts = append(opts, vrclog.WithDirIncludeRawLine(true))
 	}
 	if parseStopOnError {
 		opts = append(opts, vrclog.WithDirStopOnError(true))
 	}
+	if parseUnrecognized {
+		opts = append(opts, vrclog.WithDirUnrecognized(true))
+	}
+	if parseSource {
+		opts = append(opts, vrclog.WithDirSource(true))
+	}
+	var report vrclog.DriftReport
+	if parseDriftReport {
+		opts = append(opts, vrclog.WithDirDriftReport(&report))
+	}
 
 	// Parse all files
 	for ev, err := range vrclog.ParseDir(ctx, opts...) {
@@ -150,14 +194,32 @@ func runParse(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("parse error: %w", err)
 		}
 
+		if parseUTC {
+			ev.Timestamp = ev.Timestamp.UTC()
+		}
 		if err := OutputEvent(parseFormat, ev, os.Stdout); err != nil {
 			return fmt.Errorf("output error: %w", err)
 		}
 	}
 
+	if parseDriftReport {
+		writeDriftReport(os.Stderr, &report)
+	}
 	return nil
 }
 
+// driftReportTop is the number of shapes printed by --drift-report.
+const driftReportTop = 20
+
+// writeDriftReport prints a summary of report for --drift-report.
+func writeDriftReport(out io.Writer, report *vrclog.DriftReport) {
+	fmt.Fprintf(out, "drift report: %d of %d entries were unrecognized [Behaviour] lines\n",
+		report.Unrecognized, report.Entries)
+	for _, s := range report.Top(driftReportTop) {
+		fmt.Fprintf(out, "%8d  %s\n", s.Count, s.Shape)
+	}
+}
+
 // parseTimeRange parses since and until strings into time.Time values.
 func parseTimeRange(since, until string) (time.Time, time.Time, error) {
 	var sinceTime, untilTime time.Time
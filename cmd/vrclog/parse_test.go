@@ -1,9 +1,12 @@
 package main
 
 import (
+	"bytes"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/vrclog/vrclog-go/pkg/vrclog"
 )
 
 func TestParseTimeRange(t *testing.T) {
@@ -137,3 +140,21 @@ func TestRunParseOverlapEventTypes(t *testing.T) {
 		t.Errorf("expected overlap error, got: %v", err)
 	}
 }
+
+func TestWriteDriftReport(t *testing.T) {
+	report := vrclog.DriftReport{
+		Entries:      100,
+		Unrecognized: 3,
+		Shapes:       map[string]int{"Restored player <n>": 2, "OnPlayerJoinedNew <name> (<id>)": 1},
+	}
+
+	var buf bytes.Buffer
+	writeDriftReport(&buf, &report)
+
+	want := "drift report: 3 of 100 entries were unrecognized [Behaviour] lines\n" +
+		"       2  Restored player <n>\n" +
+		"       1  OnPlayerJoinedNew <name> (<id>)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeDriftReport() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vrclog/vrclog-go/pkg/vrclog"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the event output",
+	Long: `Print the JSON Schema (draft 2020-12) describing the events written by
+the jsonl output format.
+
+Every event carries a "schema_version" field. The version is bumped whenever
+the wire format changes, so consumers can detect records they do not know.
+The jsonl-typed format and its payloads are described under "$defs".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := cmd.OutOrStdout().Write(vrclog.JSONSchema())
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
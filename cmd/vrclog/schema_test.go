@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/vrclog/vrclog-go/pkg/vrclog"
+)
+
+func TestSchemaCmd(t *testing.T) {
+	var buf bytes.Buffer
+	schemaCmd.SetOut(&buf)
+	t.Cleanup(func() { schemaCmd.SetOut(nil) })
+
+	if err := schemaCmd.RunE(schemaCmd, nil); err != nil {
+		t.Fatalf("schema error: %v", err)
+	}
+
+	var doc struct {
+		Properties struct {
+			SchemaVersion struct {
+				Const int `json:"const"`
+			} `json:"schema_version"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("schema output is not valid JSON: %v", err)
+	}
+	if doc.Properties.SchemaVersion.Const != vrclog.SchemaVersion {
+		t.Errorf("schema_version const = %d, want %d", doc.Properties.SchemaVersion.Const, vrclog.SchemaVersion)
+	}
+}
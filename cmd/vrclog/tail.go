@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -22,6 +23,11 @@ var (
 	includeRaw       bool
 	replayLast       int
 	replaySince      string
+	tailTZ           string
+	tailUTC          bool
+	tailUnrecognized bool
+	tailSource       bool
+	flushTimeout     time.Duration
 )
 
 var tailCmd = &cobra.Command{
@@ -60,13 +66,24 @@ func init() {
 	tailCmd.Flags().StringVarP(&logDir, "log-dir", "d", "",
 		"VRChat log directory (auto-detected if not specified)")
 	tailCmd.Flags().StringVarP(&format, "format", "f", "jsonl",
-		"Output format: jsonl, pretty")
+		"Output format: jsonl, jsonl-typed, pretty")
 	tailCmd.Flags().StringSliceVar(&tailIncludeTypes, "include-types", nil,
-		"Event types to include (comma-separated: world_join,player_join,player_left)")
+		"Event types to include (comma-separated: "+strings.Join(ValidEventTypeNames(), ",")+")")
 	tailCmd.Flags().StringSliceVar(&tailExcludeTypes, "exclude-types", nil,
 		"Event types to exclude (comma-separated)")
 	tailCmd.Flags().BoolVar(&includeRaw, "raw", false,
 		"Include raw log lines in output")
+	tailCmd.Flags().StringVar(&tailTZ, "tz", "",
+		"Time zone of the log timestamps (IANA name, UTC or Local; default Local)")
+	tailCmd.Flags().BoolVar(&tailUTC, "utc", false,
+		"Output timestamps in UTC")
+	tailCmd.Flags().BoolVar(&tailUnrecognized, "unrecognized", false,
+		"Output unrecognized [Behaviour] lines as 'unrecognized' events")
+	tailCmd.Flags().BoolVar(&tailSource, "source", false,
+		"Include the source file, line, byte offset and sequence number in output")
+
+	tailCmd.Flags().DurationVar(&flushTimeout, "flush-timeout", vrclog.DefaultFlushTimeout,
+		"How long to wait for continuation lines before emitting an event (0 = no wait)")
 
 	// Replay options
 	tailCmd.Flags().IntVar(&replayLast, "replay-last", -1,
@@ -82,7 +99,7 @@ func init() {
 func runTail(cmd *cobra.Command, args []string) error {
 	// Validate format
 	if !ValidFormats[format] {
-		return fmt.Errorf("invalid format %q: must be one of: jsonl, pretty", format)
+		return fmt.Errorf("invalid format %q: must be one of: jsonl, jsonl-typed, pretty", format)
 	}
 
 	// Normalize and validate event types
@@ -103,6 +120,11 @@ func runTail(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("--replay-last and --replay-since cannot be used together")
 	}
 
+	loc, err := loadLocation(tailTZ)
+	if err != nil {
+		return err
+	}
+
 	// Setup context with signal handling
 	ctx, stop := signal.NotifyContext(context.Background(),
 		syscall.SIGINT, syscall.SIGTERM)
@@ -118,6 +140,18 @@ func runTail(cmd *cobra.Command, args []string) error {
 	if includeRaw {
 		watchOpts = append(watchOpts, vrclog.WithIncludeRawLine(true))
 	}
+	if loc != time.Local {
+		watchOpts = append(watchOpts, vrclog.WithLocation(loc))
+	}
+	if tailUnrecognized {
+		watchOpts = append(watchOpts, vrclog.WithUnrecognized(true))
+	}
+	if tailSource {
+		watchOpts = append(watchOpts, vrclog.WithSource(true))
+	}
+	if flushTimeout != vrclog.DefaultFlushTimeout {
+		watchOpts = append(watchOpts, vrclog.WithFlushTimeout(flushTimeout))
+	}
 
 	// Handle replay options
 	if replayLast >= 0 {
@@ -171,6 +205,10 @@ func runTail(cmd *cobra.Command, args []string) error {
 				return nil // Channel closed
 			}
 
+			if tailUTC {
+				event.Timestamp = event.Timestamp.UTC()
+			}
+
 			// Output event (filtering is now done at library level)
 			if err := OutputEvent(format, event, os.Stdout); err != nil {
 				return fmt.Errorf("output error: %w", err)
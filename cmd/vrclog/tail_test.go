@@ -12,6 +12,7 @@ func TestValidFormats(t *testing.T) {
 	}{
 		{"jsonl", true},
 		{"pretty", true},
+		{"jsonl-typed", true},
 		{"json", false},
 		{"xml", false},
 		{"", false},
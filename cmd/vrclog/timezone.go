@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	// Embed the time zone database so --tz works on Windows, where the
+	// system does not provide one.
+	_ "time/tzdata"
+)
+
+// loadLocation resolves a --tz flag value: an IANA time zone name such as
+// "Asia/Tokyo", "UTC", or "Local". An empty name means the local time zone.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" || strings.EqualFold(name, "Local") {
+		return time.Local, nil
+	}
+	if strings.EqualFold(name, "UTC") {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --tz %q: %w (expected an IANA name like Asia/Tokyo, UTC or Local)", name, err)
+	}
+	return loc, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadLocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty is local", input: "", want: time.Local.String()},
+		{name: "local", input: "local", want: time.Local.String()},
+		{name: "utc", input: "utc", want: "UTC"},
+		{name: "iana name", input: "Asia/Tokyo", want: "Asia/Tokyo"},
+		{name: "unknown", input: "Mars/Olympus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := loadLocation(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadLocation(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && loc.String() != tt.want {
+				t.Errorf("loadLocation(%q) = %v, want %v", tt.input, loc, tt.want)
+			}
+		})
+	}
+}
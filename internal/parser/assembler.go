@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"bytes"
+	"unsafe"
+)
+
+// MaxEntryLines is the maximum number of lines kept for a single entry.
+// Continuation lines beyond this limit are dropped to bound memory usage
+// when a log contains an unusually long stack trace.
+const MaxEntryLines = 512
+
+// Assembler groups physical log lines into log entries.
+//
+// VRChat writes exceptions and some messages as a timestamped header line
+// followed by untimestamped continuation lines (stack traces, wrapped text).
+// The Assembler appends continuation lines to the pending entry and completes
+// the entry when the next timestamped line arrives or Flush is called.
+//
+// Entries are returned as a single string with lines joined by "\n".
+// Trailing CR characters and blank lines are removed.
+// Continuation lines that arrive before any header are dropped.
+//
+// AddBytes and FlushBytes are allocation-free variants for callers that
+// read lines into a reused buffer (e.g. bufio.Scanner.Bytes).
+//
+// The zero value is ready to use. An Assembler is not safe for concurrent use.
+type Assembler struct {
+	buf     []byte // pending entry
+	out     []byte // last returned entry, reused for the next one
+	lines   int
+	pending bool
+}
+
+// Add feeds one physical line into the assembler.
+// If line starts a new entry and an entry was pending, the pending entry
+// is returned with ok == true.
+func (a *Assembler) Add(line string) (entry string, ok bool) {
+	// AddBytes copies line, so a read-only view is enough
+	b, ok := a.AddBytes(unsafe.Slice(unsafe.StringData(line), len(line)))
+	return string(b), ok
+}
+
+// AddBytes is like Add but takes and returns byte slices. The line is
+// copied. The returned entry is only valid until the next call to AddBytes,
+// Add, FlushBytes or Flush.
+func (a *Assembler) AddBytes(line []byte) (entry []byte, ok bool) {
+	line = bytes.TrimRight(line, "\r")
+
+	if !hasTimestampPrefix(unsafeString(line)) {
+		// Continuation line: append to the pending entry (if any)
+		if a.pending && a.lines < MaxEntryLines && len(bytes.TrimSpace(line)) > 0 {
+			a.buf = append(a.buf, '\n')
+			a.buf = append(a.buf, line...)
+			a.lines++
+		}
+		return nil, false
+	}
+
+	entry, ok = a.FlushBytes()
+	a.buf = append(a.buf, line...)
+	a.lines = 1
+	a.pending = true
+	return entry, ok
+}
+
+// Flush returns the pending entry, if any, and resets the assembler.
+func (a *Assembler) Flush() (entry string, ok bool) {
+	b, ok := a.FlushBytes()
+	return string(b), ok
+}
+
+// FlushBytes is like Flush but returns a byte slice, which is only valid
+// until the next call to AddBytes, Add, FlushBytes or Flush.
+func (a *Assembler) FlushBytes() (entry []byte, ok bool) {
+	if !a.pending {
+		return nil, false
+	}
+	// Swap buffers so the returned entry survives the next line being added
+	a.buf, a.out = a.out[:0], a.buf
+	a.lines = 0
+	a.pending = false
+	return a.out, true
+}
+
+// Pending reports whether an incomplete entry is buffered.
+func (a *Assembler) Pending() bool {
+	return a.pending
+}
+
+// IsEntryStart reports whether line starts a new entry, i.e. whether the
+// Assembler treats it as a header rather than a continuation line.
+// It does not allocate.
+func IsEntryStart(line []byte) bool {
+	return hasTimestampPrefix(unsafeString(line))
+}
@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAssembler(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []string
+	}{
+		{
+			name: "single line entries",
+			lines: []string{
+				"2024.01.15 12:00:00 Log        -  first",
+				"2024.01.15 12:00:01 Log        -  second",
+			},
+			want: []string{
+				"2024.01.15 12:00:00 Log        -  first",
+				"2024.01.15 12:00:01 Log        -  second",
+			},
+		},
+		{
+			name: "continuation lines are grouped with header",
+			lines: []string{
+				"2024.01.15 12:00:00 Error      -  Exception: boom",
+				"  at Foo.Bar ()",
+				"  at Foo.Baz ()",
+				"2024.01.15 12:00:01 Log        -  next",
+			},
+			want: []string{
+				"2024.01.15 12:00:00 Error      -  Exception: boom\n  at Foo.Bar ()\n  at Foo.Baz ()",
+				"2024.01.15 12:00:01 Log        -  next",
+			},
+		},
+		{
+			name: "blank lines and CR are dropped",
+			lines: []string{
+				"2024.01.15 12:00:00 Log        -  first\r",
+				"",
+				"wrapped text\r",
+				"\r",
+				"2024.01.15 12:00:01 Log        -  second\r",
+				"",
+			},
+			want: []string{
+				"2024.01.15 12:00:00 Log        -  first\nwrapped text",
+				"2024.01.15 12:00:01 Log        -  second",
+			},
+		},
+		{
+			name: "orphan continuation lines are dropped",
+			lines: []string{
+				"  at Foo.Bar ()",
+				"2024.01.15 12:00:00 Log        -  first",
+			},
+			want: []string{
+				"2024.01.15 12:00:00 Log        -  first",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a Assembler
+			var got []string
+			for _, line := range tt.lines {
+				if entry, ok := a.Add(line); ok {
+					got = append(got, entry)
+				}
+			}
+			if entry, ok := a.Flush(); ok {
+				got = append(got, entry)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d entries %q, want %d", len(got), got, len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("entry %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAssembler_Pending(t *testing.T) {
+	var a Assembler
+	if a.Pending() {
+		t.Error("zero Assembler should not be pending")
+	}
+	a.Add("2024.01.15 12:00:00 Log        -  first")
+	if !a.Pending() {
+		t.Error("Assembler should be pending after header")
+	}
+	a.Flush()
+	if a.Pending() {
+		t.Error("Assembler should not be pending after Flush")
+	}
+	if _, ok := a.Flush(); ok {
+		t.Error("second Flush should return ok == false")
+	}
+}
+
+func TestAssembler_MaxEntryLines(t *testing.T) {
+	var a Assembler
+	a.Add("2024.01.15 12:00:00 Error      -  Exception: boom")
+	for i := 0; i < MaxEntryLines+10; i++ {
+		a.Add("  at Frame ()")
+	}
+	entry, _ := a.Flush()
+	if got := strings.Count(entry, "\n") + 1; got != MaxEntryLines {
+		t.Errorf("entry has %d lines, want %d", got, MaxEntryLines)
+	}
+}
+
+func TestAssembler_AddBytes(t *testing.T) {
+	var a Assembler
+	buf := []byte("2024.01.15 12:00:00 Error      -  Exception: boom\r")
+	a.AddBytes(buf)
+
+	// The caller may reuse its buffer after AddBytes returns
+	copy(buf, "xxxxxxxxxxxxxxxxxxxxxxxx")
+	a.AddBytes([]byte("  at Foo.Bar ()"))
+
+	entry, ok := a.AddBytes([]byte("2024.01.15 12:00:01 Log        -  next"))
+	if !ok || string(entry) != "2024.01.15 12:00:00 Error      -  Exception: boom\n  at Foo.Bar ()" {
+		t.Fatalf("AddBytes() = %q, %v", entry, ok)
+	}
+	first := string(entry)
+
+	entry, ok = a.FlushBytes()
+	if !ok || string(entry) != "2024.01.15 12:00:01 Log        -  next" {
+		t.Errorf("FlushBytes() = %q, %v", entry, ok)
+	}
+	if first != "2024.01.15 12:00:00 Error      -  Exception: boom\n  at Foo.Bar ()" {
+		t.Errorf("earlier entry changed to %q", first)
+	}
+}
+
+func TestIsEntryStart(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"2024.01.15 12:00:00 Log        -  message", true},
+		{"2024.01.15 12:00:00 Error      -  Exception: boom\r", true},
+		{"  at Foo.Bar ()", false},
+		{"", false},
+		{"2024.01.15", false},
+	}
+	for _, tt := range tests {
+		if got := IsEntryStart([]byte(tt.line)); got != tt.want {
+			t.Errorf("IsEntryStart(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/vrclog/vrclog-go/pkg/vrclog/event"
+)
+
+// driftTag is the category whose unmatched lines are reported as
+// unrecognized. Nearly all built-in events come from [Behaviour] lines,
+// so a format change there usually means events silently go missing.
+const driftTag = "Behaviour"
+
+// ParseUnrecognized returns an unrecognized event for a [Behaviour] line
+// that the built-in parsers do not recognize, with the message and its
+// Shape. Callers are expected to have tried ParseInLocation first.
+//
+// Returns nil for lines with other tags, and for [Behaviour] lines that
+// are deliberately ignored or are the local player marker.
+func ParseUnrecognized(line string, loc *time.Location) *event.Event {
+	line, _, _ = strings.Cut(line, "\n")
+	line = strings.TrimRight(line, "\r")
+
+	tag, msg, ok := splitHeader(line)
+	if !ok || tag != driftTag {
+		return nil
+	}
+	for _, pattern := range exclusionPatterns {
+		if strings.Contains(line, pattern) {
+			return nil
+		}
+	}
+	if _, ok := ParseLocalPlayer(line); ok {
+		return nil
+	}
+
+	ts, err := parseTimestamp(line, loc)
+	if err != nil {
+		return nil
+	}
+	return newEvent(ts, event.UnrecognizedData{Message: msg, Shape: Shape(msg)})
+}
+
+// HasDriftTag reports whether entry is a [Behaviour] line, i.e. whether
+// ParseUnrecognized may return an event for it. It does not allocate.
+func HasDriftTag(entry []byte) bool {
+	if i := bytes.IndexByte(entry, '\n'); i >= 0 {
+		entry = entry[:i]
+	}
+	tag, _, ok := splitHeader(unsafeString(entry))
+	return ok && tag == driftTag
+}
+
+// Placeholders substituted by Shape, in order: quoted strings first, so
+// that a quoted URL becomes a single <str>.
+var shapeReplacements = []struct {
+	pattern     *regexp.Regexp
+	placeholder string
+}{
+	{regexp.MustCompile(`"[^"]*"|'[^']*'`), "<str>"},
+	{regexp.MustCompile(`https?://\S+`), "<url>"},
+	{regexp.MustCompile(`\b[a-z]{2,5}_[0-9a-fA-F-]{8,}`), "<id>"},
+	{regexp.MustCompile(`\b\d+(?:[.:,]\d+)*\b`), "<n>"},
+}
+
+// Shape normalizes a log message so that lines of the same format map to
+// the same string: URLs, quoted strings, VRChat IDs and numbers are
+// replaced with placeholders, and display names are collapsed to <name>.
+//
+// Names cannot be told apart from fixed text reliably, so Shape uses a
+// heuristic: the first word (usually the message's keyword, such as
+// "OnPlayerJoined") is kept, as are later words made of lowercase ASCII
+// letters or ending in a colon. Any other run of words becomes <name>.
+//
+//	Shape("Switching Test User to avatar Cool Avatar (avtr_xxx)")
+//	// "Switching <name> to avatar <name> (<id>)"
+func Shape(msg string) string {
+	for _, r := range shapeReplacements {
+		msg = r.pattern.ReplaceAllLiteralString(msg, r.placeholder)
+	}
+
+	words := strings.Fields(msg)
+	out := make([]string, 0, len(words))
+	for i, w := range words {
+		if i > 0 && !isShapeWord(w) {
+			w = "<name>"
+			if out[len(out)-1] == w {
+				continue
+			}
+		}
+		out = append(out, w)
+	}
+	return strings.Join(out, " ")
+}
+
+// isShapeWord reports whether w is kept as is by Shape.
+func isShapeWord(w string) bool {
+	if strings.Contains(w, "<") || strings.HasSuffix(w, ":") {
+		return true
+	}
+	w = strings.TrimRight(w, ".,;!?")
+	if w == "" {
+		return true
+	}
+	for i := 0; i < len(w); i++ {
+		if w[i] < 'a' || w[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
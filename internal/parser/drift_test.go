@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/vrclog/vrclog-go/pkg/vrclog/event"
+)
+
+func TestShape(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Restored player 12", "Restored player <n>"},
+		{"OnPlayerJoinedNew Test User (usr_12345678-1234-1234-1234-123456789abc)", "OnPlayerJoinedNew <name> (<id>)"},
+		{"Switching テストユーザー to avatar Cool Avatar", "Switching <name> to avatar <name>"},
+		{"Joining wrld_12345678-1234-1234-1234-123456789abc:12345~region(jp)", "Joining <id>:<n>~region(jp)"},
+		{"Requesting 'https://example.com/a' for Test User", "Requesting <str> for <name>"},
+		{"Loading https://example.com/a.png took 1.5 s", "Loading <url> took <n> s"},
+		{"Entering Room: Test World", "Entering Room: <name>"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Shape(tt.input); got != tt.want {
+				t.Errorf("Shape(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShape_SameFormatSameShape(t *testing.T) {
+	a := Shape("OnPlayerJoinedNew Alice (usr_11111111-1234-1234-1234-123456789abc)")
+	b := Shape("OnPlayerJoinedNew Bob Smith (usr_22222222-1234-1234-1234-123456789abc)")
+	if a != b {
+		t.Errorf("shapes differ: %q vs %q", a, b)
+	}
+}
+
+func TestParseUnrecognized(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *event.Event
+	}{
+		{
+			name:  "unmatched behaviour line",
+			input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoinedNew TestUser (usr_12345678-1234-1234-1234-123456789abc)\r",
+			want: &event.Event{
+				Type:      event.Unrecognized,
+				Timestamp: mustParseTime("2024.01.15 23:59:59"),
+				Data: event.UnrecognizedData{
+					Message: "OnPlayerJoinedNew TestUser (usr_12345678-1234-1234-1234-123456789abc)",
+					Shape:   "OnPlayerJoinedNew <name> (<id>)",
+				},
+			},
+		},
+		{
+			name:  "other category",
+			input: "2024.01.15 23:59:59 Log        -  [Network] Connected",
+		},
+		{
+			name:  "excluded line",
+			input: "2024.01.15 23:59:59 Log        -  [Behaviour] Joining or Creating Room",
+		},
+		{
+			name:  "local player marker",
+			input: `2024.01.15 23:59:59 Log        -  [Behaviour] Initialized PlayerAPI "TestUser" is local`,
+		},
+		{
+			name:  "no timestamp",
+			input: "[Behaviour] Something new",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseUnrecognized(tt.input, nil)
+			if !eventEqual(got, tt.want) {
+				t.Errorf("ParseUnrecognized() = %+v, want %+v", got, tt.want)
+			}
+			if tt.want != nil && !HasDriftTag([]byte(tt.input)) {
+				t.Error("HasDriftTag() = false for a line ParseUnrecognized accepts")
+			}
+		})
+	}
+}
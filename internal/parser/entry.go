@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"strings"
+	"time"
+
+	"github.com/vrclog/vrclog-go/pkg/vrclog/event"
+)
+
+// ParseEntry parses a VRChat log entry into a generic LogEntry.
+// The entry may span multiple lines (see Assembler).
+//
+// Returns:
+//   - (*LogEntry, nil): Successfully parsed
+//   - (nil, nil): Not a timestamped log line
+//   - (nil, error): Timestamp is present but malformed
+func ParseEntry(entry string) (*event.LogEntry, error) {
+	return ParseEntryInLocation(entry, time.Local)
+}
+
+// ParseEntryInLocation is like ParseEntry but interprets the timestamp in
+// loc (time.Local if nil).
+func ParseEntryInLocation(entry string, loc *time.Location) (*event.LogEntry, error) {
+	raw := strings.TrimRight(entry, "\r")
+	header, continuation, _ := strings.Cut(raw, "\n")
+	header = strings.TrimRight(header, "\r")
+
+	if !hasTimestampPrefix(header) {
+		return nil, nil
+	}
+	ts, err := parseTimestamp(header, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	le := &event.LogEntry{
+		Timestamp: ts,
+		Raw:       raw,
+	}
+
+	// Layout after the timestamp: " Log        -  [Category] message"
+	rest := header[timestampLen:]
+	if level, msg, ok := strings.Cut(rest, " - "); ok {
+		le.Level = event.ParseLevel(level)
+		rest = msg
+	}
+	rest = strings.TrimSpace(rest)
+
+	// Optional leading category tag
+	if strings.HasPrefix(rest, "[") {
+		if end := strings.IndexByte(rest, ']'); end > 0 {
+			le.Category = rest[1:end]
+			rest = strings.TrimSpace(rest[end+1:])
+		}
+	}
+
+	le.Message = rest
+	if continuation != "" {
+		le.Message += "\n" + continuation
+	}
+	return le, nil
+}
@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/vrclog/vrclog-go/pkg/vrclog/event"
+)
+
+func TestParseEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *event.LogEntry
+		wantErr bool
+	}{
+		{
+			name:  "behaviour line",
+			input: "2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined TestUser",
+			want: &event.LogEntry{
+				Timestamp: mustParseTime("2024.01.15 12:00:00"),
+				Level:     event.LevelLog,
+				Category:  "Behaviour",
+				Message:   "OnPlayerJoined TestUser",
+				Raw:       "2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined TestUser",
+			},
+		},
+		{
+			name:  "warning with category",
+			input: "2024.01.15 12:15:00 Warning    -  [Avatar] Avatar warning message\r",
+			want: &event.LogEntry{
+				Timestamp: mustParseTime("2024.01.15 12:15:00"),
+				Level:     event.LevelWarning,
+				Category:  "Avatar",
+				Message:   "Avatar warning message",
+				Raw:       "2024.01.15 12:15:00 Warning    -  [Avatar] Avatar warning message",
+			},
+		},
+		{
+			name:  "no category",
+			input: "2024.01.15 12:00:00 Debug      -  Initializing VRChat",
+			want: &event.LogEntry{
+				Timestamp: mustParseTime("2024.01.15 12:00:00"),
+				Level:     event.LevelDebug,
+				Message:   "Initializing VRChat",
+				Raw:       "2024.01.15 12:00:00 Debug      -  Initializing VRChat",
+			},
+		},
+		{
+			name:  "multi-line error",
+			input: "2024.01.15 12:00:00 Error      -  [UdonBehaviour] An exception occurred\n  at Foo.Bar ()",
+			want: &event.LogEntry{
+				Timestamp: mustParseTime("2024.01.15 12:00:00"),
+				Level:     event.LevelError,
+				Category:  "UdonBehaviour",
+				Message:   "An exception occurred\n  at Foo.Bar ()",
+				Raw:       "2024.01.15 12:00:00 Error      -  [UdonBehaviour] An exception occurred\n  at Foo.Bar ()",
+			},
+		},
+		{
+			name:  "not a log line",
+			input: "  at Foo.Bar ()",
+			want:  nil,
+		},
+		{
+			name:    "malformed timestamp",
+			input:   "2024.13.45 25:61:61 Log        -  [Behaviour] Broken",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEntry(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEntry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("ParseEntry() = %+v, want %+v", got, tt.want)
+			}
+			if got == nil {
+				return
+			}
+			if !got.Timestamp.Equal(tt.want.Timestamp) || got.Level != tt.want.Level ||
+				got.Category != tt.want.Category || got.Message != tt.want.Message || got.Raw != tt.want.Raw {
+				t.Errorf("ParseEntry() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
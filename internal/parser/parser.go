@@ -3,6 +3,8 @@ package parser
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,14 +13,37 @@ import (
 
 // Parse parses a VRChat log line into an Event.
 //
+// The line may be a multi-line entry assembled by an Assembler; only the
+// first (timestamped) line is matched against the built-in event patterns.
+// Continuation lines are only read for events that carry them (udon_exception).
+//
 // Returns:
 //   - (*Event, nil): Successfully parsed
 //   - (nil, nil): Not a recognized event pattern
 //   - (nil, error): Malformed line
+//
+// Timestamps are interpreted in the local time zone; see ParseInLocation.
 func Parse(line string) (*event.Event, error) {
+	return ParseInLocation(line, time.Local)
+}
+
+// ParseInLocation is like Parse but interprets the log timestamp, which
+// has no UTC offset, in loc (time.Local if nil). Wall-clock times that
+// occur twice at a daylight saving transition resolve to the first
+// occurrence, as with time.ParseInLocation.
+func ParseInLocation(line string, loc *time.Location) (*event.Event, error) {
+	// Built-in patterns only look at the header line of multi-line entries
+	line, continuation, _ := strings.Cut(line, "\n")
+
 	// Trim trailing CR for Windows CRLF compatibility
 	line = strings.TrimRight(line, "\r")
 
+	// Cheap prefilter: most lines cannot be events
+	tag, msg, ok := splitHeader(line)
+	if !ok || !isCandidate(tag, msg) {
+		return nil, nil
+	}
+
 	// Quick exclusion check
 	for _, pattern := range exclusionPatterns {
 		if strings.Contains(line, pattern) {
@@ -27,21 +52,24 @@ func Parse(line string) (*event.Event, error) {
 	}
 
 	// Extract timestamp
-	ts, err := parseTimestamp(line)
+	ts, err := parseTimestamp(line, loc)
 	if err != nil {
 		// No timestamp means not a standard log line
 		return nil, nil
 	}
 
-	// Try each event pattern
-	if ev := parsePlayerJoin(line, ts); ev != nil {
-		return ev, nil
-	}
-	if ev := parsePlayerLeft(line, ts); ev != nil {
-		return ev, nil
+	// Try the event patterns for the line's category
+	if tag == "UdonBehaviour" {
+		return parseUdonException(line, continuation, ts), nil
 	}
-	if ev := parseWorldJoin(line, ts); ev != nil {
-		return ev, nil
+	specs := specsFor(tag)
+	for i := range specs {
+		if !specs[i].accepts(msg) {
+			continue
+		}
+		if ev := specs[i].parse(line, ts); ev != nil {
+			return ev, nil
+		}
 	}
 
 	// Not a recognized event
@@ -51,7 +79,7 @@ func Parse(line string) (*event.Event, error) {
 // timestampLen is the length of VRChat log timestamps ("2024.01.15 23:59:59")
 const timestampLen = 19
 
-func parseTimestamp(line string) (time.Time, error) {
+func parseTimestamp(line string, loc *time.Location) (time.Time, error) {
 	// VRChat log timestamps are always 19 characters at the start
 	// Format: "2024.01.15 23:59:59"
 	if len(line) < timestampLen {
@@ -59,12 +87,24 @@ func parseTimestamp(line string) (time.Time, error) {
 	}
 
 	// Quick validation: check for expected format markers
-	ts := line[:timestampLen]
-	if ts[4] != '.' || ts[7] != '.' || ts[10] != ' ' || ts[13] != ':' || ts[16] != ':' {
+	if !hasTimestampPrefix(line) {
 		return time.Time{}, fmt.Errorf("invalid timestamp format")
 	}
 
-	return time.ParseInLocation(timestampLayout, ts, time.Local)
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.ParseInLocation(timestampLayout, line[:timestampLen], loc)
+}
+
+// hasTimestampPrefix reports whether line starts with something shaped like
+// a VRChat log timestamp. It only checks the format markers, not the digits.
+func hasTimestampPrefix(line string) bool {
+	if len(line) < timestampLen {
+		return false
+	}
+	ts := line[:timestampLen]
+	return ts[4] == '.' && ts[7] == '.' && ts[10] == ' ' && ts[13] == ':' && ts[16] == ':'
 }
 
 func parsePlayerJoin(line string, ts time.Time) *event.Event {
@@ -73,17 +113,7 @@ func parsePlayerJoin(line string, ts time.Time) *event.Event {
 		return nil
 	}
 
-	ev := &event.Event{
-		Type:       event.PlayerJoin,
-		Timestamp:  ts,
-		PlayerName: strings.TrimSpace(match[1]),
-	}
-
-	if len(match) > 2 && match[2] != "" {
-		ev.PlayerID = match[2]
-	}
-
-	return ev
+	return newEvent(ts, playerData(match))
 }
 
 func parsePlayerLeft(line string, ts time.Time) *event.Event {
@@ -92,32 +122,435 @@ func parsePlayerLeft(line string, ts time.Time) *event.Event {
 		return nil
 	}
 
-	return &event.Event{
-		Type:       event.PlayerLeft,
-		Timestamp:  ts,
+	return newEvent(ts, event.PlayerLeftData(playerData(match)))
+}
+
+// playerData builds the payload of a player_join or player_left event
+// from a playerJoinPattern or playerLeftPattern match.
+func playerData(match []string) event.PlayerJoinData {
+	d := event.PlayerJoinData{
 		PlayerName: strings.TrimSpace(match[1]),
+		PlayerID:   match[2],
+	}
+	if match[3] != "" {
+		d.ActorNumber, _ = strconv.Atoi(match[3])
 	}
+	return d
+}
+
+// newEvent returns an event of d's type at ts.
+func newEvent(ts time.Time, d event.Data) *event.Event {
+	ev := event.New(ts, d)
+	return &ev
 }
 
 func parseWorldJoin(line string, ts time.Time) *event.Event {
 	// Try "Entering Room" first (has world name)
 	if match := enteringRoomPattern.FindStringSubmatch(line); match != nil {
-		return &event.Event{
-			Type:      event.WorldJoin,
-			Timestamp: ts,
-			WorldName: strings.TrimSpace(match[1]),
-		}
+		return newEvent(ts, event.WorldJoinData{WorldName: strings.TrimSpace(match[1])})
 	}
 
 	// Try "Joining" (has world ID and instance ID)
 	if match := joiningPattern.FindStringSubmatch(line); match != nil {
-		return &event.Event{
-			Type:       event.WorldJoin,
-			Timestamp:  ts,
-			WorldID:    match[1],
-			InstanceID: match[2],
+		d := event.WorldJoinData{WorldID: match[1], InstanceID: match[2]}
+		d.Instance, d.GroupID = parseInstance(d.InstanceID)
+		return newEvent(ts, d)
+	}
+
+	return nil
+}
+
+func parseWorldLeave(line string, ts time.Time) *event.Event {
+	if !worldLeavePattern.MatchString(line) {
+		return nil
+	}
+
+	return newEvent(ts, event.WorldLeaveData{})
+}
+
+func parseDisconnect(line string, ts time.Time) *event.Event {
+	match := disconnectPattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+
+	return newEvent(ts, event.DisconnectData{Reason: strings.TrimSpace(match[1])})
+}
+
+// parseInstance returns the structured form of instanceID, and the group
+// ID for group instances. Structured instance info is best-effort; the raw
+// ID is always kept, and info is nil if it cannot be parsed.
+func parseInstance(instanceID string) (info *event.InstanceInfo, groupID string) {
+	if instanceID == "" {
+		return nil, ""
+	}
+	i, err := event.ParseInstanceID(instanceID)
+	if err != nil {
+		return nil, ""
+	}
+	if i.AccessType == event.AccessGroup {
+		groupID = i.OwnerID
+	}
+	return &i, groupID
+}
+
+func parseAvatarChange(line string, ts time.Time) *event.Event {
+	match := avatarChangePattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+
+	return newEvent(ts, event.AvatarChangeData{
+		PlayerName: strings.TrimSpace(match[1]),
+		AvatarName: strings.TrimSpace(match[2]),
+		AvatarID:   match[3],
+	})
+}
+
+func parseVideoPlay(line string, ts time.Time) *event.Event {
+	// Cheap prefilter: all video lines share the same category tag
+	if !strings.Contains(line, "[Video Playback]") {
+		return nil
+	}
+
+	if match := videoRequestPattern.FindStringSubmatch(line); match != nil {
+		return newEvent(ts, event.VideoPlayData{VideoURL: match[1]})
+	}
+
+	if match := videoResolvedPattern.FindStringSubmatch(line); match != nil {
+		return newEvent(ts, event.VideoPlayData{VideoURL: match[1], ResolvedURL: match[2]})
+	}
+
+	if match := videoErrorPattern.FindStringSubmatch(line); match != nil {
+		return newEvent(ts, event.VideoPlayData{VideoError: strings.TrimSpace(match[1])})
+	}
+
+	return nil
+}
+
+func parseScreenshot(line string, ts time.Time) *event.Event {
+	match := screenshotPattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+
+	return newEvent(ts, event.ScreenshotData{ScreenshotPath: strings.TrimSpace(match[1])})
+}
+
+func parseSelfAuthenticated(line string, ts time.Time) *event.Event {
+	match := selfAuthenticatedPattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+
+	return newEvent(ts, event.SelfAuthenticatedData{
+		PlayerName: strings.TrimSpace(match[1]),
+		PlayerID:   match[2],
+	})
+}
+
+func parseNotification(line string, ts time.Time) *event.Event {
+	match := notificationPattern.FindStringSubmatchIndex(line)
+	if match == nil {
+		return nil
+	}
+
+	d := event.NotificationData{
+		PlayerName: line[match[2]:match[3]],
+		PlayerID:   line[match[4]:match[5]],
+	}
+
+	rest := line[match[1]:]
+	if m := notificationTypePattern.FindStringSubmatch(rest); m != nil {
+		d.NotificationType = m[1]
+	}
+	if m := notificationIDPattern.FindStringSubmatch(rest); m != nil {
+		d.NotificationID = m[1]
+	}
+	if m := notificationDetailsPattern.FindStringSubmatch(rest); m != nil {
+		d.Details = parseNotificationDetails(m[1])
+	}
+	if m := notificationMessagePattern.FindStringSubmatch(rest); m != nil {
+		d.Message = m[1]
+	}
+
+	// Invites carry the target location in their details
+	if loc := d.Details["worldId"]; loc != "" {
+		d.WorldID, d.InstanceID, _ = strings.Cut(loc, ":")
+		d.Instance, d.GroupID = parseInstance(d.InstanceID)
+	}
+	d.WorldName = d.Details["worldName"]
+	if d.GroupID == "" {
+		d.GroupID = d.Details["groupId"]
+	}
+
+	// Group notifications (e.g. "group", "groupAnnouncement") get their own type
+	if strings.HasPrefix(strings.ToLower(d.NotificationType), "group") {
+		return newEvent(ts, event.GroupNotificationData(d))
+	}
+	return newEvent(ts, d)
+}
+
+// parseNotificationDetails parses "key=value, key=value" into a map.
+// Values may themselves contain ", " (e.g. world names); a new pair only
+// starts at ", " followed by "key=". Returns nil for empty details.
+func parseNotificationDetails(s string) map[string]string {
+	keys := notificationDetailKeyPattern.FindAllStringSubmatchIndex(s, -1)
+	if keys == nil {
+		return nil
+	}
+
+	details := make(map[string]string, len(keys))
+	for i, k := range keys {
+		end := len(s)
+		if i+1 < len(keys) {
+			end = keys[i+1][0]
+		}
+		details[s[k[2]:k[3]]] = s[k[1]:end]
+	}
+	return details
+}
+
+func parseUdonException(line, continuation string, ts time.Time) *event.Event {
+	if !udonExceptionPattern.MatchString(line) {
+		return nil
+	}
+
+	lines := strings.Split(strings.ReplaceAll(continuation, "\r", ""), "\n")
+	d := event.UdonExceptionData{
+		ExceptionMessage: udonExceptionMessage(lines),
+		StackTrace:       strings.TrimRight(strings.Join(lines, "\n"), "\n"),
+	}
+	if m := udonObjectPattern.FindStringSubmatch(line + "\n" + continuation); m != nil {
+		d.ObjectName = strings.TrimSpace(m[1])
+	}
+	return newEvent(ts, d)
+}
+
+// udonExceptionMessage extracts the exception message from the lines
+// following an Udon exception header. VRChat logs the message in an
+// "Exception Message:" block; without one, the first line (usually
+// "ExceptionType: message") is used.
+func udonExceptionMessage(lines []string) string {
+	var msg []string
+	inBlock := false
+	for _, l := range lines {
+		l = strings.TrimSpace(l)
+		if !inBlock {
+			inBlock = l == "Exception Message:"
+			continue
+		}
+		if strings.HasPrefix(l, "--->") || strings.HasPrefix(l, "at ") {
+			break
+		}
+		if l != "" {
+			msg = append(msg, l)
+		}
+	}
+	if len(msg) > 0 {
+		return strings.Join(msg, "\n")
+	}
+	if len(lines) > 0 {
+		return strings.TrimSpace(lines[0])
+	}
+	return ""
+}
+
+func parseRemoteDownload(line string, ts time.Time) *event.Event {
+	if match := remoteDownloadRequestPattern.FindStringSubmatch(line); match != nil {
+		return newEvent(ts, event.RemoteDownloadData{
+			DownloadKind:   strings.ToLower(match[1]),
+			DownloadURL:    match[2],
+			DownloadStatus: "requested",
+		})
+	}
+	if match := remoteDownloadErrorPattern.FindStringSubmatch(line); match != nil {
+		return newEvent(ts, event.RemoteDownloadData{
+			DownloadKind:   strings.ToLower(match[1]),
+			DownloadURL:    match[2],
+			DownloadStatus: "failed",
+			DownloadError:  strings.TrimSpace(match[3]),
+		})
+	}
+	if match := remoteDownloadSuccessPattern.FindStringSubmatch(line); match != nil {
+		return newEvent(ts, event.RemoteDownloadData{
+			DownloadKind:   strings.ToLower(match[1]),
+			DownloadURL:    match[2],
+			DownloadStatus: "succeeded",
+		})
+	}
+	return nil
+}
+
+// assetDownloadStatuses maps the action in assetDownloadPattern to
+// AssetDownloadData.DownloadStatus values.
+var assetDownloadStatuses = map[string]string{
+	"Starting download of": "started",
+	"Finished download of": "completed",
+	"Unpacking":            "unpacking",
+	"Failed to download":   "failed",
+	"Failed to load":       "failed",
+}
+
+// assetSizeUnits maps the size units in assetDownloadPattern to bytes.
+var assetSizeUnits = map[string]float64{
+	"bytes": 1,
+	"B":     1,
+	"KB":    1 << 10,
+	"MB":    1 << 20,
+	"GB":    1 << 30,
+}
+
+func parseAssetDownload(line string, ts time.Time) *event.Event {
+	match := assetDownloadPattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+
+	d := event.AssetDownloadData{
+		DownloadStatus: assetDownloadStatuses[match[1]],
+		DownloadKind:   strings.ToLower(match[2]),
+		AssetID:        match[3],
+		DownloadError:  strings.TrimSpace(match[6]),
+	}
+	if match[4] != "" {
+		if n, err := strconv.ParseFloat(match[4], 64); err == nil {
+			d.AssetSize = int64(n * assetSizeUnits[match[5]])
+		}
+	}
+	return newEvent(ts, d)
+}
+
+func parsePortalDrop(line string, ts time.Time) *event.Event {
+	if match := portalDropPattern.FindStringSubmatch(line); match != nil {
+		d := event.PortalDropData{
+			PlayerName: strings.TrimSpace(match[1]),
+			PlayerID:   match[2],
+			WorldID:    match[3],
+			InstanceID: match[4],
 		}
+		d.Instance, d.GroupID = parseInstance(d.InstanceID)
+		return newEvent(ts, d)
+	}
+
+	if match := portalConfigurePattern.FindStringSubmatch(line); match != nil {
+		return newEvent(ts, event.PortalDropData{PlayerName: strings.TrimSpace(match[1])})
 	}
 
 	return nil
 }
+
+func parseItemSpawn(line string, ts time.Time) *event.Event {
+	match := itemSpawnPattern.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+
+	d := event.StickerSpawnData{
+		PlayerID:   match[1],
+		PlayerName: strings.TrimSpace(match[2]),
+		ItemID:     match[4],
+	}
+	// The item kind in itemSpawnPattern selects the event type
+	switch match[3] {
+	case "emoji":
+		return newEvent(ts, event.EmojiSpawnData(d))
+	case "print":
+		return newEvent(ts, event.PrintPlaceData(d))
+	}
+	return newEvent(ts, d)
+}
+
+// moderationActions maps the past-tense verbs in moderationPattern to
+// ModerationData.ModerationAction values.
+var moderationActions = map[string]string{
+	"kicked":    "kick",
+	"banned":    "ban",
+	"warned":    "warn",
+	"blocked":   "block",
+	"unblocked": "unblock",
+	"muted":     "mute",
+	"unmuted":   "unmute",
+}
+
+func parseModeration(line string, ts time.Time) *event.Event {
+	if match := voteKickPattern.FindStringSubmatch(line); match != nil {
+		return newEvent(ts, event.ModerationData{
+			ModerationAction: "vote_kick",
+			TargetName:       strings.TrimSpace(match[1]),
+			TargetID:         match[2],
+			PlayerName:       strings.TrimSpace(match[3]),
+			PlayerID:         match[4],
+		})
+	}
+
+	if match := moderationPattern.FindStringSubmatch(line); match != nil {
+		return newEvent(ts, event.ModerationData{
+			ModerationAction: moderationActions[match[3]],
+			TargetName:       strings.TrimSpace(match[1]),
+			TargetID:         match[2],
+			PlayerName:       strings.TrimSpace(match[4]),
+			PlayerID:         match[5],
+		})
+	}
+
+	return nil
+}
+
+// parseAppStart parses one client information line into a partial
+// app_start event with a single field set.
+func parseAppStart(line string, ts time.Time) *event.Event {
+	var d event.AppStartData
+	switch {
+	case matchInto(appBuildPattern, line, &d.BuildVersion):
+	case matchInto(appUnityPattern, line, &d.UnityVersion):
+	case matchInto(appCommandLinePattern, line, &d.CommandLine):
+	case matchInto(appXRDevicePattern, line, &d.VRMode):
+		if strings.EqualFold(d.VRMode, "None") {
+			d.VRMode = "desktop"
+		} else {
+			d.VRMode = "vr"
+		}
+	default:
+		return nil
+	}
+	return newEvent(ts, d)
+}
+
+func parseAppQuit(line string, ts time.Time) *event.Event {
+	if !appQuitPattern.MatchString(line) {
+		return nil
+	}
+	return newEvent(ts, event.AppQuitData{})
+}
+
+// matchInto stores the trimmed first capture group of pattern in dst
+// and reports whether line matched.
+func matchInto(pattern *regexp.Regexp, line string, dst *string) bool {
+	match := pattern.FindStringSubmatch(line)
+	if match == nil {
+		return false
+	}
+	*dst = strings.TrimSpace(match[1])
+	return true
+}
+
+// ParseLocalPlayer extracts the local player's display name from an
+// "Initialized PlayerAPI "Name" is local" line. These lines do not produce
+// events; callers use them to recognize the local user in later events.
+//
+// Returns the display name and true if line marks the local player.
+func ParseLocalPlayer(line string) (string, bool) {
+	line, _, _ = strings.Cut(line, "\n")
+	line = strings.TrimRight(line, "\r")
+
+	// Cheap prefilter before running the regex on every line
+	if !strings.HasSuffix(line, "is local") {
+		return "", false
+	}
+	match := localPlayerPattern.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
@@ -1,149 +1,893 @@
 package parser
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/vrclog/vrclog-go/pkg/vrclog/event"
 )
 
-func TestParse(t *testing.T) {
-	tests := []struct {
-		name    string
-		input   string
-		want    *event.Event
-		wantErr bool
-	}{
-		// Player join events
-		{
-			name:  "player join without ID",
-			input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser",
-			want: &event.Event{
-				Type:       event.PlayerJoin,
-				Timestamp:  mustParseTime("2024.01.15 23:59:59"),
+// parseTests are the cases of TestParse. TestParse_AnyTag reuses the
+// inputs with other category tags.
+var parseTests = []struct {
+	name    string
+	input   string
+	want    *event.Event
+	wantErr bool
+}{
+	// Player join events
+	{
+		name:  "player join without ID",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser",
+		want: &event.Event{
+			Type:      event.PlayerJoin,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PlayerJoinData{
 				PlayerName: "TestUser",
 			},
 		},
-		{
-			name:  "player join with ID",
-			input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser (usr_12345678-1234-1234-1234-123456789abc)",
-			want: &event.Event{
-				Type:       event.PlayerJoin,
-				Timestamp:  mustParseTime("2024.01.15 23:59:59"),
+	},
+	{
+		name:  "player join with ID",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser (usr_12345678-1234-1234-1234-123456789abc)",
+		want: &event.Event{
+			Type:      event.PlayerJoin,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PlayerJoinData{
 				PlayerName: "TestUser",
 				PlayerID:   "usr_12345678-1234-1234-1234-123456789abc",
 			},
 		},
-		{
-			name:  "player join with spaces in name",
-			input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined Test User Name",
-			want: &event.Event{
-				Type:       event.PlayerJoin,
-				Timestamp:  mustParseTime("2024.01.15 23:59:59"),
+	},
+	{
+		name:  "player join with spaces in name",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined Test User Name",
+		want: &event.Event{
+			Type:      event.PlayerJoin,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PlayerJoinData{
 				PlayerName: "Test User Name",
 			},
 		},
-		{
-			name:  "player join with japanese name",
-			input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined テストユーザー",
-			want: &event.Event{
-				Type:       event.PlayerJoin,
-				Timestamp:  mustParseTime("2024.01.15 23:59:59"),
+	},
+	{
+		name:  "player join with japanese name",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined テストユーザー",
+		want: &event.Event{
+			Type:      event.PlayerJoin,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PlayerJoinData{
 				PlayerName: "テストユーザー",
 			},
 		},
+	},
 
-		// Player left events
-		{
-			name:  "player left",
-			input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerLeft TestUser",
-			want: &event.Event{
-				Type:       event.PlayerLeft,
-				Timestamp:  mustParseTime("2024.01.15 23:59:59"),
+	{
+		name:  "player join with ID and actor number",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser (usr_12345678-1234-1234-1234-123456789abc) [3]",
+		want: &event.Event{
+			Type:      event.PlayerJoin,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PlayerJoinData{
+				PlayerName:  "TestUser",
+				PlayerID:    "usr_12345678-1234-1234-1234-123456789abc",
+				ActorNumber: 3,
+			},
+		},
+	},
+
+	// Player left events
+	{
+		name:  "player left",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerLeft TestUser",
+		want: &event.Event{
+			Type:      event.PlayerLeft,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PlayerLeftData{
 				PlayerName: "TestUser",
 			},
 		},
-		{
-			name:  "player left with special char name",
-			input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerLeft (Special) Name",
-			want: &event.Event{
-				Type:       event.PlayerLeft,
-				Timestamp:  mustParseTime("2024.01.15 23:59:59"),
+	},
+	{
+		name:  "player left with special char name",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerLeft (Special) Name",
+		want: &event.Event{
+			Type:      event.PlayerLeft,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PlayerLeftData{
 				PlayerName: "(Special) Name",
 			},
 		},
+	},
+	{
+		name:  "player left with ID",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerLeft TestUser (usr_12345678-1234-1234-1234-123456789abc)",
+		want: &event.Event{
+			Type:      event.PlayerLeft,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PlayerLeftData{
+				PlayerName: "TestUser",
+				PlayerID:   "usr_12345678-1234-1234-1234-123456789abc",
+			},
+		},
+	},
+	{
+		name:  "player left with ID and actor number",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerLeft TestUser (usr_12345678-1234-1234-1234-123456789abc) [12]",
+		want: &event.Event{
+			Type:      event.PlayerLeft,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PlayerLeftData{
+				PlayerName:  "TestUser",
+				PlayerID:    "usr_12345678-1234-1234-1234-123456789abc",
+				ActorNumber: 12,
+			},
+		},
+	},
+	{
+		name:  "player left with bracketed name suffix",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerLeft TestUser [12]",
+		want: &event.Event{
+			Type:      event.PlayerLeft,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PlayerLeftData{
+				PlayerName: "TestUser [12]",
+			},
+		},
+	},
 
-		// World join events
-		{
-			name:  "entering room",
-			input: "2024.01.15 23:59:59 Log        -  [Behaviour] Entering Room: Test World",
-			want: &event.Event{
-				Type:      event.WorldJoin,
-				Timestamp: mustParseTime("2024.01.15 23:59:59"),
+	// World join events
+	{
+		name:  "entering room",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] Entering Room: Test World",
+		want: &event.Event{
+			Type:      event.WorldJoin,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.WorldJoinData{
 				WorldName: "Test World",
 			},
 		},
-		{
-			name:  "entering room with special chars",
-			input: "2024.01.15 23:59:59 Log        -  [Behaviour] Entering Room: Test [World] (v1.0)",
-			want: &event.Event{
-				Type:      event.WorldJoin,
-				Timestamp: mustParseTime("2024.01.15 23:59:59"),
+	},
+	{
+		name:  "entering room with special chars",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] Entering Room: Test [World] (v1.0)",
+		want: &event.Event{
+			Type:      event.WorldJoin,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.WorldJoinData{
 				WorldName: "Test [World] (v1.0)",
 			},
 		},
-		{
-			name:  "joining world with instance",
-			input: "2024.01.15 23:59:59 Log        -  [Behaviour] Joining wrld_12345678-1234-1234-1234-123456789abc:12345~region(us)",
-			want: &event.Event{
-				Type:       event.WorldJoin,
-				Timestamp:  mustParseTime("2024.01.15 23:59:59"),
+	},
+	{
+		name:  "joining world with instance",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] Joining wrld_12345678-1234-1234-1234-123456789abc:12345~region(us)",
+		want: &event.Event{
+			Type:      event.WorldJoin,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.WorldJoinData{
 				WorldID:    "wrld_12345678-1234-1234-1234-123456789abc",
 				InstanceID: "12345~region(us)",
+				Instance: &event.InstanceInfo{
+					Name:       "12345",
+					AccessType: event.AccessPublic,
+					Region:     "us",
+				},
+			},
+		},
+	},
+	{
+		name:  "joining invite+ instance",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] Joining wrld_12345678-1234-1234-1234-123456789abc:67890~private(usr_12345678-1234-1234-1234-123456789abc)~canRequestInvite~region(jp)~nonce(abc123)",
+		want: &event.Event{
+			Type:      event.WorldJoin,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.WorldJoinData{
+				WorldID:    "wrld_12345678-1234-1234-1234-123456789abc",
+				InstanceID: "67890~private(usr_12345678-1234-1234-1234-123456789abc)~canRequestInvite~region(jp)~nonce(abc123)",
+				Instance: &event.InstanceInfo{
+					Name:             "67890",
+					AccessType:       event.AccessInvitePlus,
+					OwnerID:          "usr_12345678-1234-1234-1234-123456789abc",
+					Region:           "jp",
+					Nonce:            "abc123",
+					CanRequestInvite: true,
+				},
 			},
 		},
+	},
 
-		// Unrecognized lines (should return nil, nil)
-		{
-			name:    "unrecognized line",
-			input:   "2024.01.15 23:59:59 Log        -  [Network] Connected",
-			want:    nil,
-			wantErr: false,
+	// World leave and disconnect events
+	{
+		name:  "world leave",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnLeftRoom",
+		want: &event.Event{
+			Type:      event.WorldLeave,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data:      event.WorldLeaveData{},
 		},
-		{
-			name:    "empty line",
-			input:   "",
-			want:    nil,
-			wantErr: false,
+	},
+	{
+		name:  "OnPlayerLeftRoom is not a second world leave",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerLeftRoom",
+		want:  nil,
+	},
+	{
+		name:  "disconnect with reason",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnDisconnected: ClientTimeout",
+		want: &event.Event{
+			Type:      event.Disconnect,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.DisconnectData{
+				Reason: "ClientTimeout",
+			},
 		},
+	},
+	{
+		name:  "lost connection with reason",
+		input: "2024.01.15 23:59:59 Warning    -  [Network Processing] Lost connection to master: ServerTimeout",
+		want: &event.Event{
+			Type:      event.Disconnect,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.DisconnectData{
+				Reason: "ServerTimeout",
+			},
+		},
+	},
+	{
+		name:  "disconnect without reason",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] Disconnected",
+		want: &event.Event{
+			Type:      event.Disconnect,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data:      event.DisconnectData{},
+		},
+	},
+	{
+		name:  "disconnected mid-message is not a disconnect",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] Controller Disconnected",
+		want:  nil,
+	},
 
-		// Exclusion patterns
-		{
-			name:    "exclusion: OnPlayerLeftRoom",
-			input:   "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerLeftRoom",
-			want:    nil,
-			wantErr: false,
+	{
+		name:  "joining group instance",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] Joining wrld_12345678-1234-1234-1234-123456789abc:12345~group(grp_12345678-1234-1234-1234-123456789abc)~groupAccessType(plus)~region(jp)",
+		want: &event.Event{
+			Type:      event.WorldJoin,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.WorldJoinData{
+				WorldID:    "wrld_12345678-1234-1234-1234-123456789abc",
+				InstanceID: "12345~group(grp_12345678-1234-1234-1234-123456789abc)~groupAccessType(plus)~region(jp)",
+				GroupID:    "grp_12345678-1234-1234-1234-123456789abc",
+				Instance: &event.InstanceInfo{
+					Name:            "12345",
+					AccessType:      event.AccessGroup,
+					OwnerID:         "grp_12345678-1234-1234-1234-123456789abc",
+					GroupAccessType: "plus",
+					Region:          "jp",
+				},
+			},
 		},
-		{
-			name:    "exclusion: Joining or Creating",
-			input:   "2024.01.15 23:59:59 Log        -  [Behaviour] Joining or Creating Room",
-			want:    nil,
-			wantErr: false,
+	},
+
+	// Avatar change events
+	{
+		name:  "avatar change",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] Switching TestUser to avatar Cool Avatar",
+		want: &event.Event{
+			Type:      event.AvatarChange,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.AvatarChangeData{
+				PlayerName: "TestUser",
+				AvatarName: "Cool Avatar",
+			},
+		},
+	},
+	{
+		name:  "avatar change with ID",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] Switching Test User to avatar Cool Avatar (avtr_12345678-1234-1234-1234-123456789abc)",
+		want: &event.Event{
+			Type:      event.AvatarChange,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.AvatarChangeData{
+				PlayerName: "Test User",
+				AvatarName: "Cool Avatar",
+				AvatarID:   "avtr_12345678-1234-1234-1234-123456789abc",
+			},
 		},
+	},
 
-		// Windows CRLF compatibility
-		{
-			name:  "CRLF line ending",
-			input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser\r",
-			want: &event.Event{
-				Type:       event.PlayerJoin,
-				Timestamp:  mustParseTime("2024.01.15 23:59:59"),
+	// Video playback events
+	{
+		name:  "video url requested",
+		input: "2024.01.15 23:59:59 Log        -  [Video Playback] Attempting to resolve URL 'https://www.youtube.com/watch?v=abc123'",
+		want: &event.Event{
+			Type:      event.VideoPlay,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.VideoPlayData{
+				VideoURL: "https://www.youtube.com/watch?v=abc123",
+			},
+		},
+	},
+	{
+		name:  "video url resolved",
+		input: "2024.01.15 23:59:59 Log        -  [Video Playback] URL 'https://www.youtube.com/watch?v=abc123' resolved to 'https://rr1.googlevideo.com/videoplayback?id=abc'",
+		want: &event.Event{
+			Type:      event.VideoPlay,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.VideoPlayData{
+				VideoURL:    "https://www.youtube.com/watch?v=abc123",
+				ResolvedURL: "https://rr1.googlevideo.com/videoplayback?id=abc",
+			},
+		},
+	},
+	{
+		name:  "video url resolution error",
+		input: "2024.01.15 23:59:59 Error      -  [Video Playback] ERROR: Video unavailable",
+		want: &event.Event{
+			Type:      event.VideoPlay,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.VideoPlayData{
+				VideoError: "Video unavailable",
+			},
+		},
+	},
+	{
+		name:  "other video playback line",
+		input: "2024.01.15 23:59:59 Log        -  [Video Playback] Resolving URL with yt-dlp",
+		want:  nil,
+	},
+
+	// Screenshot events
+	{
+		name:  "screenshot",
+		input: `2024.01.15 23:59:59 Log        -  [VRC Camera] Took screenshot to: C:\Users\Test\Pictures\VRChat\2024-01\VRChat_2024-01-15_23-59-59.123_1920x1080.png`,
+		want: &event.Event{
+			Type:      event.Screenshot,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.ScreenshotData{
+				ScreenshotPath: `C:\Users\Test\Pictures\VRChat\2024-01\VRChat_2024-01-15_23-59-59.123_1920x1080.png`,
+			},
+		},
+	},
+
+	// Self authenticated events
+	{
+		name:  "user authenticated",
+		input: "2024.01.15 23:59:59 Log        -  User Authenticated: Test User (usr_12345678-1234-1234-1234-123456789abc)",
+		want: &event.Event{
+			Type:      event.SelfAuthenticated,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.SelfAuthenticatedData{
+				PlayerName: "Test User",
+				PlayerID:   "usr_12345678-1234-1234-1234-123456789abc",
+			},
+		},
+	},
+	{
+		name:  "local player api line is not an event",
+		input: `2024.01.15 23:59:59 Log        -  [Behaviour] Initialized PlayerAPI "TestUser" is local`,
+		want:  nil,
+	},
+
+	// Notification events
+	{
+		name:  "invite notification",
+		input: `2024.01.15 23:59:59 Log        -  Received Notification: <Notification from username:Sender Name, sender user id:usr_12345678-1234-1234-1234-123456789abc to of type: invite, id: not_12345678-1234-1234-1234-123456789abc, created at: 01/15/2024 14:59:59 UTC, details: {{worldId=wrld_12345678-1234-1234-1234-123456789abc:12345~private(usr_12345678-1234-1234-1234-123456789abc)~region(jp), worldName=Cats, Dogs and Friends}}, type:invite, m seen:False, message: ""> received at 01/15/2024 14:59:59 UTC`,
+		want: &event.Event{
+			Type:      event.Notification,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.NotificationData{
+				PlayerName:       "Sender Name",
+				PlayerID:         "usr_12345678-1234-1234-1234-123456789abc",
+				NotificationType: "invite",
+				NotificationID:   "not_12345678-1234-1234-1234-123456789abc",
+				WorldID:          "wrld_12345678-1234-1234-1234-123456789abc",
+				WorldName:        "Cats, Dogs and Friends",
+				InstanceID:       "12345~private(usr_12345678-1234-1234-1234-123456789abc)~region(jp)",
+				Instance: &event.InstanceInfo{
+					Name:       "12345",
+					AccessType: event.AccessInvite,
+					OwnerID:    "usr_12345678-1234-1234-1234-123456789abc",
+					Region:     "jp",
+				},
+				Details: map[string]string{
+					"worldId":   "wrld_12345678-1234-1234-1234-123456789abc:12345~private(usr_12345678-1234-1234-1234-123456789abc)~region(jp)",
+					"worldName": "Cats, Dogs and Friends",
+				},
+			},
+		},
+	},
+	{
+		name:  "friend request notification",
+		input: `2024.01.15 23:59:59 Log        -  Received Notification: <Notification from username:Sender, sender user id:usr_12345678-1234-1234-1234-123456789abc to of type: friendRequest, id: not_12345678-1234-1234-1234-123456789abc, created at: 01/15/2024 14:59:59 UTC, details: {{}}, type:friendRequest, m seen:False, message: "hi there"> received at 01/15/2024 14:59:59 UTC`,
+		want: &event.Event{
+			Type:      event.Notification,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.NotificationData{
+				PlayerName:       "Sender",
+				PlayerID:         "usr_12345678-1234-1234-1234-123456789abc",
+				NotificationType: "friendRequest",
+				NotificationID:   "not_12345678-1234-1234-1234-123456789abc",
+				Message:          "hi there",
+			},
+		},
+	},
+
+	// Udon exception events
+	{
+		name: "udon exception with message block",
+		input: "2024.01.15 23:59:59 Error      -  [UdonBehaviour] An exception occurred during Udon execution, this UdonBehaviour will be halted.\n" +
+			"VRC.Udon.VM.UdonVMException: The VM encountered an error!\n" +
+			"Exception Message:\n" +
+			"  An exception occurred during EXTERN to 'UnityEngineTransform.__get_position__UnityEngineVector3'.\n" +
+			"      Parameter Addresses: 0x00000004, 0x00000005\n" +
+			"  Object reference not set to an instance of an object.\n" +
+			" ---> System.NullReferenceException: Object reference not set to an instance of an object.\n" +
+			"  at VRC.Udon.VM.UdonVM.Interpret () [0x00000] in <00000000000000000000000000000000>:0 ",
+		want: &event.Event{
+			Type:      event.UdonException,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.UdonExceptionData{
+				ExceptionMessage: "An exception occurred during EXTERN to 'UnityEngineTransform.__get_position__UnityEngineVector3'.\n" +
+					"Parameter Addresses: 0x00000004, 0x00000005\n" +
+					"Object reference not set to an instance of an object.",
+				StackTrace: "VRC.Udon.VM.UdonVMException: The VM encountered an error!\n" +
+					"Exception Message:\n" +
+					"  An exception occurred during EXTERN to 'UnityEngineTransform.__get_position__UnityEngineVector3'.\n" +
+					"      Parameter Addresses: 0x00000004, 0x00000005\n" +
+					"  Object reference not set to an instance of an object.\n" +
+					" ---> System.NullReferenceException: Object reference not set to an instance of an object.\n" +
+					"  at VRC.Udon.VM.UdonVM.Interpret () [0x00000] in <00000000000000000000000000000000>:0 ",
+			},
+		},
+	},
+	{
+		name: "udon exception with object name",
+		input: "2024.01.15 23:59:59 Error      -  [UdonBehaviour] An exception occurred during Udon execution, this UdonBehaviour will be halted.\r\n" +
+			"System.InvalidOperationException: Sequence contains no elements\r\n" +
+			"  on GameObject 'Door Switch'\r",
+		want: &event.Event{
+			Type:      event.UdonException,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.UdonExceptionData{
+				ObjectName:       "Door Switch",
+				ExceptionMessage: "System.InvalidOperationException: Sequence contains no elements",
+				StackTrace:       "System.InvalidOperationException: Sequence contains no elements\n  on GameObject 'Door Switch'",
+			},
+		},
+	},
+	{
+		name:  "udon exception without trace",
+		input: "2024.01.15 23:59:59 Error      -  [UdonBehaviour] An exception occurred during Udon execution, this UdonBehaviour will be halted.",
+		want: &event.Event{
+			Type:      event.UdonException,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data:      event.UdonExceptionData{},
+		},
+	},
+
+	// Application lifecycle events
+	{
+		name:  "app start build",
+		input: "2024.01.15 23:59:59 Log        -  VRChat Build: 2024.1.1p2-1409--Release",
+		want: &event.Event{
+			Type:      event.AppStart,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.AppStartData{
+				BuildVersion: "2024.1.1p2-1409--Release",
+			},
+		},
+	},
+	{
+		name:  "app start unity version",
+		input: "2024.01.15 23:59:59 Log        -  Unity Version: 2022.3.6f1-DWR",
+		want: &event.Event{
+			Type:      event.AppStart,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.AppStartData{
+				UnityVersion: "2022.3.6f1-DWR",
+			},
+		},
+	},
+	{
+		name:  "app start desktop mode",
+		input: "2024.01.15 23:59:59 Log        -  XR Device: None",
+		want: &event.Event{
+			Type:      event.AppStart,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.AppStartData{
+				VRMode: "desktop",
+			},
+		},
+	},
+	{
+		name:  "app start vr mode",
+		input: "2024.01.15 23:59:59 Log        -  XR Device: OpenXR Display",
+		want: &event.Event{
+			Type:      event.AppStart,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.AppStartData{
+				VRMode: "vr",
+			},
+		},
+	},
+	{
+		name:  "app start command line",
+		input: "2024.01.15 23:59:59 Log        -  Command line arguments: --no-vr --profile=0",
+		want: &event.Event{
+			Type:      event.AppStart,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.AppStartData{
+				CommandLine: "--no-vr --profile=0",
+			},
+		},
+	},
+	{
+		name:  "build inside another message is not app start",
+		input: "2024.01.15 23:59:59 Log        -  [Network] Server says VRChat Build: outdated",
+		want:  nil,
+	},
+	{
+		name:  "app quit",
+		input: "2024.01.15 23:59:59 Debug      -  VRCApplication: OnApplicationQuit at 1234.56",
+		want: &event.Event{
+			Type:      event.AppQuit,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data:      event.AppQuitData{},
+		},
+	},
+
+	// Remote download events
+	{
+		name:  "string download requested",
+		input: "2024.01.15 23:59:59 Log        -  [String Download] Attempting to load String from URL 'https://example.com/data.json'",
+		want: &event.Event{
+			Type:      event.RemoteDownload,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.RemoteDownloadData{
+				DownloadKind:   "string",
+				DownloadURL:    "https://example.com/data.json",
+				DownloadStatus: "requested",
+			},
+		},
+	},
+	{
+		name:  "image download requested",
+		input: "2024.01.15 23:59:59 Log        -  [Image Download] Attempting to load image from URL 'https://example.com/a.png'",
+		want: &event.Event{
+			Type:      event.RemoteDownload,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.RemoteDownloadData{
+				DownloadKind:   "image",
+				DownloadURL:    "https://example.com/a.png",
+				DownloadStatus: "requested",
+			},
+		},
+	},
+	{
+		name:  "string download failed with URL",
+		input: "2024.01.15 23:59:59 Error      -  [String Download] Failed to load String from URL 'https://example.com/data.json': HTTP/1.1 404 Not Found",
+		want: &event.Event{
+			Type:      event.RemoteDownload,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.RemoteDownloadData{
+				DownloadKind:   "string",
+				DownloadURL:    "https://example.com/data.json",
+				DownloadStatus: "failed",
+				DownloadError:  "HTTP/1.1 404 Not Found",
+			},
+		},
+	},
+	{
+		name:  "image download error without URL",
+		input: "2024.01.15 23:59:59 Error      -  [Image Download] Error: Image is too large",
+		want: &event.Event{
+			Type:      event.RemoteDownload,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.RemoteDownloadData{
+				DownloadKind:   "image",
+				DownloadStatus: "failed",
+				DownloadError:  "Image is too large",
+			},
+		},
+	},
+	{
+		name:  "image download succeeded",
+		input: "2024.01.15 23:59:59 Log        -  [Image Download] Successfully downloaded image from URL 'https://example.com/a.png'",
+		want: &event.Event{
+			Type:      event.RemoteDownload,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.RemoteDownloadData{
+				DownloadKind:   "image",
+				DownloadURL:    "https://example.com/a.png",
+				DownloadStatus: "succeeded",
+			},
+		},
+	},
+	{
+		name:  "other download line",
+		input: "2024.01.15 23:59:59 Log        -  [String Download] Queue length 3",
+		want:  nil,
+	},
+
+	// Asset download events
+	{
+		name:  "avatar download started",
+		input: "2024.01.15 23:59:59 Log        -  [AssetBundleDownloadManager] [12] Starting download of Avatar avtr_12345678-1234-1234-1234-123456789abc",
+		want: &event.Event{
+			Type:      event.AssetDownload,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.AssetDownloadData{
+				DownloadKind:   "avatar",
+				AssetID:        "avtr_12345678-1234-1234-1234-123456789abc",
+				DownloadStatus: "started",
+			},
+		},
+	},
+	{
+		name:  "world download finished with bytes",
+		input: "2024.01.15 23:59:59 Log        -  [AssetBundleDownloadManager] [3] Finished download of World wrld_12345678-1234-1234-1234-123456789abc, 12845056 bytes",
+		want: &event.Event{
+			Type:      event.AssetDownload,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.AssetDownloadData{
+				DownloadKind:   "world",
+				AssetID:        "wrld_12345678-1234-1234-1234-123456789abc",
+				DownloadStatus: "completed",
+				AssetSize:      12845056,
+			},
+		},
+	},
+	{
+		name:  "avatar unpacking with MB size",
+		input: "2024.01.15 23:59:59 Log        -  [AssetBundleDownloadManager] [12] Unpacking Avatar avtr_12345678-1234-1234-1234-123456789abc (1.5 MB)",
+		want: &event.Event{
+			Type:      event.AssetDownload,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.AssetDownloadData{
+				DownloadKind:   "avatar",
+				AssetID:        "avtr_12345678-1234-1234-1234-123456789abc",
+				DownloadStatus: "unpacking",
+				AssetSize:      1572864,
+			},
+		},
+	},
+	{
+		name:  "avatar load failed",
+		input: "2024.01.15 23:59:59 Error      -  [AssetBundleDownloadManager] [12] Failed to load Avatar avtr_12345678-1234-1234-1234-123456789abc: Incompatible asset bundle",
+		want: &event.Event{
+			Type:      event.AssetDownload,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.AssetDownloadData{
+				DownloadKind:   "avatar",
+				AssetID:        "avtr_12345678-1234-1234-1234-123456789abc",
+				DownloadStatus: "failed",
+				DownloadError:  "Incompatible asset bundle",
+			},
+		},
+	},
+	{
+		name:  "other asset bundle line",
+		input: "2024.01.15 23:59:59 Log        -  [AssetBundleDownloadManager] [12] Download queue empty",
+		want:  nil,
+	},
+
+	// Portal, sticker, emoji and print events
+	{
+		name:  "portal dropped with target",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] Test User (usr_12345678-1234-1234-1234-123456789abc) dropped portal to wrld_12345678-1234-1234-1234-123456789abc:12345~region(jp)",
+		want: &event.Event{
+			Type:      event.PortalDrop,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PortalDropData{
+				PlayerName: "Test User",
+				PlayerID:   "usr_12345678-1234-1234-1234-123456789abc",
+				WorldID:    "wrld_12345678-1234-1234-1234-123456789abc",
+				InstanceID: "12345~region(jp)",
+				Instance: &event.InstanceInfo{
+					Name:       "12345",
+					AccessType: event.AccessPublic,
+					Region:     "jp",
+				},
+			},
+		},
+	},
+	{
+		name:  "portal configured",
+		input: "2024.01.15 23:59:59 Log        -  [Network Processing] RPC invoked ConfigurePortal on (Clone [800004] Portals/PortalInternalDynamic) for TestUser",
+		want: &event.Event{
+			Type:      event.PortalDrop,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PortalDropData{
 				PlayerName: "TestUser",
 			},
 		},
-	}
+	},
+	{
+		name:  "sticker spawned",
+		input: "2024.01.15 23:59:59 Log        -  [StickersManager] User usr_12345678-1234-1234-1234-123456789abc (Test User) spawned sticker inv_12345678-1234-1234-1234-123456789abc",
+		want: &event.Event{
+			Type:      event.StickerSpawn,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.StickerSpawnData{
+				PlayerName: "Test User",
+				PlayerID:   "usr_12345678-1234-1234-1234-123456789abc",
+				ItemID:     "inv_12345678-1234-1234-1234-123456789abc",
+			},
+		},
+	},
+	{
+		name:  "emoji spawned",
+		input: "2024.01.15 23:59:59 Log        -  [EmojiManager] User usr_12345678-1234-1234-1234-123456789abc (TestUser) spawned emoji",
+		want: &event.Event{
+			Type:      event.EmojiSpawn,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.EmojiSpawnData{
+				PlayerName: "TestUser",
+				PlayerID:   "usr_12345678-1234-1234-1234-123456789abc",
+			},
+		},
+	},
+	{
+		name:  "print placed",
+		input: "2024.01.15 23:59:59 Log        -  [PrintManager] User usr_12345678-1234-1234-1234-123456789abc (TestUser) placed print prnt_12345678-1234-1234-1234-123456789abc",
+		want: &event.Event{
+			Type:      event.PrintPlace,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PrintPlaceData{
+				PlayerName: "TestUser",
+				PlayerID:   "usr_12345678-1234-1234-1234-123456789abc",
+				ItemID:     "prnt_12345678-1234-1234-1234-123456789abc",
+			},
+		},
+	},
 
-	for _, tt := range tests {
+	// Moderation events
+	{
+		name:  "vote kick initiated",
+		input: "2024.01.15 23:59:59 Log        -  [ModerationManager] A vote kick has been initiated against Bad User, do you agree?",
+		want: &event.Event{
+			Type:      event.Moderation,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.ModerationData{
+				ModerationAction: "vote_kick",
+				TargetName:       "Bad User",
+			},
+		},
+	},
+	{
+		name:  "vote kick with actor",
+		input: "2024.01.15 23:59:59 Log        -  [ModerationManager] A vote kick has been initiated against Bad User (usr_11111111-1234-1234-1234-123456789abc) by Mod User (usr_22222222-1234-1234-1234-123456789abc), do you agree?",
+		want: &event.Event{
+			Type:      event.Moderation,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.ModerationData{
+				ModerationAction: "vote_kick",
+				TargetName:       "Bad User",
+				TargetID:         "usr_11111111-1234-1234-1234-123456789abc",
+				PlayerName:       "Mod User",
+				PlayerID:         "usr_22222222-1234-1234-1234-123456789abc",
+			},
+		},
+	},
+	{
+		name:  "kicked",
+		input: "2024.01.15 23:59:59 Log        -  [ModerationManager] Bad User has been kicked",
+		want: &event.Event{
+			Type:      event.Moderation,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.ModerationData{
+				ModerationAction: "kick",
+				TargetName:       "Bad User",
+			},
+		},
+	},
+	{
+		name:  "muted by actor",
+		input: "2024.01.15 23:59:59 Log        -  [ModerationManager] Loud User (usr_11111111-1234-1234-1234-123456789abc) has been muted by Mod User.",
+		want: &event.Event{
+			Type:      event.Moderation,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.ModerationData{
+				ModerationAction: "mute",
+				TargetName:       "Loud User",
+				TargetID:         "usr_11111111-1234-1234-1234-123456789abc",
+				PlayerName:       "Mod User",
+			},
+		},
+	},
+	{
+		name:  "unblocked",
+		input: "2024.01.15 23:59:59 Log        -  [ModerationManager] Some User has been unblocked",
+		want: &event.Event{
+			Type:      event.Moderation,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.ModerationData{
+				ModerationAction: "unblock",
+				TargetName:       "Some User",
+			},
+		},
+	},
+	{
+		name:  "other moderation line",
+		input: "2024.01.15 23:59:59 Log        -  [ModerationManager] Requesting moderations",
+		want:  nil,
+	},
+
+	{
+		name:  "group notification",
+		input: `2024.01.15 23:59:59 Log        -  Received Notification: <Notification from username:Group Admin, sender user id:usr_12345678-1234-1234-1234-123456789abc to of type: groupAnnouncement, id: not_12345678-1234-1234-1234-123456789abc, created at: 01/15/2024 14:59:59 UTC, details: {{groupId=grp_12345678-1234-1234-1234-123456789abc, title=Meetup tonight}}, type:groupAnnouncement, m seen:False, message: ""> received at 01/15/2024 14:59:59 UTC`,
+		want: &event.Event{
+			Type:      event.GroupNotification,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.GroupNotificationData{
+				PlayerName:       "Group Admin",
+				PlayerID:         "usr_12345678-1234-1234-1234-123456789abc",
+				NotificationType: "groupAnnouncement",
+				NotificationID:   "not_12345678-1234-1234-1234-123456789abc",
+				GroupID:          "grp_12345678-1234-1234-1234-123456789abc",
+				Details: map[string]string{
+					"groupId": "grp_12345678-1234-1234-1234-123456789abc",
+					"title":   "Meetup tonight",
+				},
+			},
+		},
+	},
+
+	// Unrecognized lines (should return nil, nil)
+	{
+		name:    "unrecognized line",
+		input:   "2024.01.15 23:59:59 Log        -  [Network] Connected",
+		want:    nil,
+		wantErr: false,
+	},
+	{
+		name:    "empty line",
+		input:   "",
+		want:    nil,
+		wantErr: false,
+	},
+
+	// Exclusion patterns
+	{
+		name:    "exclusion: Joining or Creating",
+		input:   "2024.01.15 23:59:59 Log        -  [Behaviour] Joining or Creating Room",
+		want:    nil,
+		wantErr: false,
+	},
+
+	// Multi-line entries (header is matched, continuation ignored)
+	{
+		name:  "multi-line entry matches header",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser\r\ncontinuation text",
+		want: &event.Event{
+			Type:      event.PlayerJoin,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PlayerJoinData{
+				PlayerName: "TestUser",
+			},
+		},
+	},
+
+	// Windows CRLF compatibility
+	{
+		name:  "CRLF line ending",
+		input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser\r",
+		want: &event.Event{
+			Type:      event.PlayerJoin,
+			Timestamp: mustParseTime("2024.01.15 23:59:59"),
+			Data: event.PlayerJoinData{
+				PlayerName: "TestUser",
+			},
+		},
+	},
+}
+
+func TestParse(t *testing.T) {
+	for _, tt := range parseTests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := Parse(tt.input)
 
@@ -157,10 +901,143 @@ func TestParse(t *testing.T) {
 			if !eventEqual(got, tt.want) {
 				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
 			}
+
+			// The byte-slice fast path must agree with Parse
+			if tt.want != nil && !MayMatch([]byte(tt.input)) {
+				t.Errorf("MayMatch() = false for a line Parse recognizes")
+			}
+
+			// The prefilter must not change the result
+			if got, _ := parseUnfiltered(tt.input, time.Local); !eventEqual(got, tt.want) {
+				t.Errorf("parseUnfiltered() = %+v, want %+v", got, tt.want)
+			}
+
+			// The payload must belong to the event's type
+			if got != nil && got.Data.EventType() != got.Type {
+				t.Errorf("Data is %T for a %s event", got.Data, got.Type)
+			}
+		})
+	}
+}
+
+// TestParse_AnyTag moves every TestParse input under each category tag the
+// prefilter knows, and under no tag, and checks that tag dispatch finds
+// the same event as trying every parser.
+func TestParse_AnyTag(t *testing.T) {
+	tags := []string{"", "API", "Always"}
+	for tag := range tagParsers {
+		tags = append(tags, tag)
+	}
+
+	for _, tt := range parseTests {
+		for _, tag := range tags {
+			line, ok := retag(tt.input, tag)
+			if !ok {
+				continue
+			}
+			want, _ := parseUnfiltered(line, time.Local)
+			got, _ := ParseInLocation(line, time.Local)
+			if !eventEqual(got, want) {
+				t.Errorf("%s under [%s]: ParseInLocation() = %+v, want %+v", tt.name, tag, got, want)
+			}
+		}
+	}
+}
+
+func TestParse_Tagged(t *testing.T) {
+	ts := mustParseTime("2024.01.15 23:59:59")
+	tests := []struct {
+		name  string
+		input string
+		want  *event.Event
+	}{
+		{
+			name:  "self authenticated",
+			input: "2024.01.15 23:59:59 Log        -  [Behaviour] User Authenticated: Test User (usr_12345678-1234-1234-1234-123456789abc)",
+			want: &event.Event{
+				Type:      event.SelfAuthenticated,
+				Timestamp: ts,
+				Data: event.SelfAuthenticatedData{
+					PlayerName: "Test User",
+					PlayerID:   "usr_12345678-1234-1234-1234-123456789abc",
+				},
+			},
+		},
+		{
+			name:  "notification",
+			input: `2024.01.15 23:59:59 Log        -  [Behaviour] Received Notification: <Notification from username:Sender, sender user id:usr_12345678-1234-1234-1234-123456789abc to of type: friendRequest, id: not_12345678-1234-1234-1234-123456789abc, created at: 01/15/2024 14:59:59 UTC, details: {{}}, type:friendRequest, m seen:False, message: "hi there"> received at 01/15/2024 14:59:59 UTC`,
+			want: &event.Event{
+				Type:      event.Notification,
+				Timestamp: ts,
+				Data: event.NotificationData{
+					PlayerName:       "Sender",
+					PlayerID:         "usr_12345678-1234-1234-1234-123456789abc",
+					NotificationType: "friendRequest",
+					NotificationID:   "not_12345678-1234-1234-1234-123456789abc",
+					Message:          "hi there",
+				},
+			},
+		},
+		{
+			name:  "group notification",
+			input: `2024.01.15 23:59:59 Log        -  [API] Received Notification: <Notification from username:Group Admin, sender user id:usr_12345678-1234-1234-1234-123456789abc to of type: groupAnnouncement, id: not_12345678-1234-1234-1234-123456789abc, created at: 01/15/2024 14:59:59 UTC, details: {{groupId=grp_12345678-1234-1234-1234-123456789abc}}, type:groupAnnouncement, m seen:False, message: ""> received at 01/15/2024 14:59:59 UTC`,
+			want: &event.Event{
+				Type:      event.GroupNotification,
+				Timestamp: ts,
+				Data: event.GroupNotificationData{
+					PlayerName:       "Group Admin",
+					PlayerID:         "usr_12345678-1234-1234-1234-123456789abc",
+					NotificationType: "groupAnnouncement",
+					NotificationID:   "not_12345678-1234-1234-1234-123456789abc",
+					Details:          map[string]string{"groupId": "grp_12345678-1234-1234-1234-123456789abc"},
+					GroupID:          "grp_12345678-1234-1234-1234-123456789abc",
+				},
+			},
+		},
+		{
+			name:  "app quit",
+			input: "2024.01.15 23:59:59 Log        -  [Behaviour] VRCApplication: OnApplicationQuit at 1234.56",
+			want:  &event.Event{Type: event.AppQuit, Timestamp: ts, Data: event.AppQuitData{}},
+		},
+		{
+			name:  "disconnect",
+			input: "2024.01.15 23:59:59 Log        -  [Network Processing] Disconnected: ClientTimeout",
+			want:  &event.Event{Type: event.Disconnect, Timestamp: ts, Data: event.DisconnectData{Reason: "ClientTimeout"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !eventEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
 		})
 	}
 }
 
+// retag returns line with its category tag replaced by tag, or removed if
+// tag is empty. ok is false if line is not a timestamped log line.
+func retag(line, tag string) (string, bool) {
+	header, _, _ := strings.Cut(line, "\n")
+	old, msg, ok := splitHeader(header)
+	if !ok {
+		return "", false
+	}
+	head := strings.TrimRight(header[:len(header)-len(msg)], " ")
+	if old != "" {
+		head = strings.TrimRight(strings.TrimSuffix(head, "["+old+"]"), " ")
+	}
+	head += "  "
+	if tag != "" {
+		head += "[" + tag + "] "
+	}
+	return head + line[len(header)-len(msg):], true
+}
+
 func TestParse_Parallel(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -171,18 +1048,22 @@ func TestParse_Parallel(t *testing.T) {
 			name:  "player join",
 			input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser",
 			want: &event.Event{
-				Type:       event.PlayerJoin,
-				Timestamp:  mustParseTime("2024.01.15 23:59:59"),
-				PlayerName: "TestUser",
+				Type:      event.PlayerJoin,
+				Timestamp: mustParseTime("2024.01.15 23:59:59"),
+				Data: event.PlayerJoinData{
+					PlayerName: "TestUser",
+				},
 			},
 		},
 		{
 			name:  "player left",
 			input: "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerLeft TestUser",
 			want: &event.Event{
-				Type:       event.PlayerLeft,
-				Timestamp:  mustParseTime("2024.01.15 23:59:59"),
-				PlayerName: "TestUser",
+				Type:      event.PlayerLeft,
+				Timestamp: mustParseTime("2024.01.15 23:59:59"),
+				Data: event.PlayerLeftData{
+					PlayerName: "TestUser",
+				},
 			},
 		},
 	}
@@ -200,11 +1081,82 @@ func TestParse_Parallel(t *testing.T) {
 	}
 }
 
+func TestParseInLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	line := "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser"
+
+	ev, err := ParseInLocation(line, tokyo)
+	if err != nil || ev == nil {
+		t.Fatalf("ParseInLocation() = %v, %v", ev, err)
+	}
+	want := time.Date(2024, 1, 15, 14, 59, 59, 0, time.UTC)
+	if !ev.Timestamp.Equal(want) {
+		t.Errorf("Timestamp = %v, want %v", ev.Timestamp, want)
+	}
+	if ev.Timestamp.Location() != tokyo {
+		t.Errorf("Timestamp location = %v, want %v", ev.Timestamp.Location(), tokyo)
+	}
+
+	// nil means time.Local
+	ev, _ = ParseInLocation(line, nil)
+	if ev == nil || !ev.Timestamp.Equal(mustParseTime("2024.01.15 23:59:59")) {
+		t.Errorf("ParseInLocation(nil) = %+v, want local time", ev)
+	}
+
+	entry, err := ParseEntryInLocation(line, tokyo)
+	if err != nil || entry == nil || !entry.Timestamp.Equal(want) {
+		t.Errorf("ParseEntryInLocation() = %+v, %v, want timestamp %v", entry, err, want)
+	}
+}
+
+func TestParseLocalPlayer(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantName string
+		wantOK   bool
+	}{
+		{
+			name:     "local player",
+			input:    `2024.01.15 23:59:59 Log        -  [Behaviour] Initialized PlayerAPI "Test User" is local`,
+			wantName: "Test User",
+			wantOK:   true,
+		},
+		{
+			name:     "local player with CRLF",
+			input:    "2024.01.15 23:59:59 Log        -  [Behaviour] Initialized PlayerAPI \"TestUser\" is local\r",
+			wantName: "TestUser",
+			wantOK:   true,
+		},
+		{
+			name:   "remote player",
+			input:  `2024.01.15 23:59:59 Log        -  [Behaviour] Initialized PlayerAPI "TestUser" is remote`,
+			wantOK: false,
+		},
+		{
+			name:   "unrelated line",
+			input:  "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := ParseLocalPlayer(tt.input)
+			if ok != tt.wantOK || name != tt.wantName {
+				t.Errorf("ParseLocalPlayer() = (%q, %v), want (%q, %v)", name, ok, tt.wantName, tt.wantOK)
+			}
+		})
+	}
+}
+
 func FuzzParse(f *testing.F) {
 	// Seed corpus
 	f.Add("2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser")
 	f.Add("2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerLeft TestUser")
 	f.Add("2024.01.15 23:59:59 Log        -  [Behaviour] Entering Room: Test World")
+	f.Add("2024.01.15 23:59:59 Log        -  [Behaviour] Switching TestUser to avatar Cool Avatar")
+	f.Add("2024.01.15 23:59:59 Log        -  [Video Playback] URL 'https://example.com/a' resolved to 'https://example.com/b'")
 	f.Add("")
 	f.Add("invalid line")
 	f.Add("2024.01.15 23:59:59 Log        -  [Network] Connected")
@@ -234,9 +1186,113 @@ func eventEqual(a, b *event.Event) bool {
 	}
 	return a.Type == b.Type &&
 		a.Timestamp.Equal(b.Timestamp) &&
-		a.PlayerName == b.PlayerName &&
-		a.PlayerID == b.PlayerID &&
-		a.WorldID == b.WorldID &&
-		a.WorldName == b.WorldName &&
-		a.InstanceID == b.InstanceID
+		reflect.DeepEqual(a.Data, b.Data)
+}
+
+// benchLines is a log excerpt with the typical mix of VRChat log lines:
+// mostly lines that are not events, a few that are.
+var benchLines = []string{
+	"2024.01.15 23:59:59 Debug      -  [Always] uSpeak: SetInputDevice 0 (3 total) 'Microphone (Realtek Audio)'",
+	"2024.01.15 23:59:59 Log        -  [Network Processing] Sending ping to server",
+	"2024.01.15 23:59:59 Log        -  [Behaviour] Restored player 12",
+	"2024.01.15 23:59:59 Debug      -  Loading asset bundle from cache",
+	"2024.01.15 23:59:59 Warning    -  [Avatar] Avatar is too complex, some features disabled",
+	"2024.01.15 23:59:59 Log        -  [Always] Received 3 udon sync packets",
+	"2024.01.15 23:59:59 Log        -  [Behaviour] Initialized PlayerAPI \"TestUser\" is remote",
+	"2024.01.15 23:59:59 Debug      -  [Image Download] Queue length 0",
+	"2024.01.15 23:59:59 Log        -  [API] Fetching user usr_12345678-1234-1234-1234-123456789abc",
+	"2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser (usr_12345678-1234-1234-1234-123456789abc)",
+	"2024.01.15 23:59:59 Log        -  [Behaviour] Switching TestUser to avatar Cool Avatar",
+	"2024.01.15 23:59:59 Log        -  [Video Playback] Attempting to resolve URL 'https://www.youtube.com/watch?v=abc123'",
+}
+
+// benchEventLines is the number of lines in benchLines that are events.
+const benchEventLines = 3
+
+// BenchmarkParse compares tag dispatch with trying every parser on every
+// line (parseUnfiltered).
+func BenchmarkParse(b *testing.B) {
+	benchParse(b, benchLines, false)
+}
+
+func TestMayMatch_NoAllocs(t *testing.T) {
+	lines := make([][]byte, len(benchLines))
+	for i, line := range benchLines {
+		lines[i] = []byte(line)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, line := range lines {
+			MayMatch(line)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("MayMatch allocated %v times per run, want 0", allocs)
+	}
+}
+
+func TestMayMatch_LocalPlayer(t *testing.T) {
+	line := []byte(`2024.01.15 23:59:59 Log        -  [Behaviour] Initialized PlayerAPI "TestUser" is local`)
+	if !MayMatch(line) {
+		t.Error("MayMatch() = false for the local player marker")
+	}
+}
+
+func BenchmarkParse_NonMatching(b *testing.B) {
+	benchParse(b, benchLines[:len(benchLines)-benchEventLines], true)
+}
+
+// benchParse runs the "prefilter" and "unfiltered" sub-benchmarks over lines.
+// If none is true, no line may produce an event.
+func benchParse(b *testing.B, lines []string, none bool) {
+	for _, bm := range []struct {
+		name  string
+		parse func(string, *time.Location) (*event.Event, error)
+	}{
+		{"prefilter", ParseInLocation},
+		{"unfiltered", parseUnfiltered},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				for _, line := range lines {
+					ev, err := bm.parse(line, time.Local)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if none && ev != nil {
+						b.Fatalf("unexpected event for %q", line)
+					}
+				}
+			}
+		})
+	}
+}
+
+// parseUnfiltered is ParseInLocation without the prefilter: every parser
+// is tried on every line, regardless of its tag and markers. Tests compare
+// ParseInLocation against it, and the "unfiltered" benchmarks measure the
+// cost of tag dispatch with it.
+func parseUnfiltered(line string, loc *time.Location) (*event.Event, error) {
+	line, continuation, _ := strings.Cut(line, "\n")
+	line = strings.TrimRight(line, "\r")
+
+	for _, pattern := range exclusionPatterns {
+		if strings.Contains(line, pattern) {
+			return nil, nil
+		}
+	}
+	ts, err := parseTimestamp(line, loc)
+	if err != nil {
+		return nil, nil
+	}
+
+	if ev := parseUdonException(line, continuation, ts); ev != nil {
+		return ev, nil
+	}
+	for _, spec := range parserSpecs {
+		if ev := spec.parse(line, ts); ev != nil {
+			return ev, nil
+		}
+	}
+	return nil, nil
 }
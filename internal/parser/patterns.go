@@ -9,16 +9,21 @@ const timestampLayout = "2006.01.02 15:04:05"
 var (
 	// Matches: "[Behaviour] OnPlayerJoined DisplayName"
 	// Matches: "[Behaviour] OnPlayerJoined DisplayName (usr_xxx)"
-	// Captures: (1) display name, (2) user ID (optional)
+	// Matches: "[Behaviour] OnPlayerJoined DisplayName (usr_xxx) [12]" (Photon actor number)
+	// The actor number is only recognized after a user ID, so that display
+	// names ending in brackets are not misread.
+	// Captures: (1) display name, (2) user ID (optional), (3) actor number (optional)
 	playerJoinPattern = regexp.MustCompile(
-		`\[Behaviour\] OnPlayerJoined (.+?)(?:\s+\((usr_[a-f0-9-]+)\))?$`,
+		`\[Behaviour\] OnPlayerJoined (.+?)(?:\s+\((usr_[a-f0-9-]+)\)(?:\s+\[(\d+)\])?)?$`,
 	)
 
 	// Matches: "[Behaviour] OnPlayerLeft DisplayName"
-	// Note: OnPlayerLeftRoom is handled by exclusionPatterns
-	// Captures: (1) display name
+	// Matches: "[Behaviour] OnPlayerLeft DisplayName (usr_xxx)"
+	// Matches: "[Behaviour] OnPlayerLeft DisplayName (usr_xxx) [12]"
+	// Note: OnPlayerLeftRoom (no space) is the local user leaving, see exclusionPatterns
+	// Captures: (1) display name, (2) user ID (optional), (3) actor number (optional)
 	playerLeftPattern = regexp.MustCompile(
-		`\[Behaviour\] OnPlayerLeft (.+)$`,
+		`\[Behaviour\] OnPlayerLeft (.+?)(?:\s+\((usr_[a-f0-9-]+)\)(?:\s+\[(\d+)\])?)?$`,
 	)
 
 	// Matches: "[Behaviour] Entering Room: World Name"
@@ -33,12 +38,199 @@ var (
 	joiningPattern = regexp.MustCompile(
 		`\[Behaviour\] Joining (wrld_[a-f0-9-]+):(.+)$`,
 	)
+
+	// Matches: "[Behaviour] OnLeftRoom"
+	// OnPlayerLeftRoom, logged for the same departure, is excluded so that
+	// each leave yields one world_leave even when parsing single lines.
+	worldLeavePattern = regexp.MustCompile(
+		`\[Behaviour\] OnLeftRoom$`,
+	)
+
+	// Matches: "[Behaviour] OnDisconnected: ClientTimeout"
+	// Matches: "[Network Processing] Lost connection to master: ServerTimeout"
+	// Matches: "[Behaviour] Disconnected"
+	// Captures: (1) reason (optional)
+	disconnectPattern = regexp.MustCompile(
+		`\[[^\]]+\] (?:OnDisconnected|Disconnected|Lost connection(?: to \w+)?)(?::\s*(.*))?$`,
+	)
+
+	// Matches: "Received Notification: <Notification from username:Name, sender user id:usr_xxx
+	//          to of type: invite, id: not_xxx, ..., details: {{worldId=..., worldName=...}},
+	//          type:invite, m seen:False, message: "..."> received at ..."
+	// Captures: (1) sender display name, (2) sender user ID
+	// The remaining fields are extracted by the notification*Pattern below.
+	notificationPattern = regexp.MustCompile(
+		`Received Notification: <Notification from username:(.*?), sender user id:(usr_[a-f0-9-]+)`,
+	)
+	notificationTypePattern    = regexp.MustCompile(`\bof type: ?(\w+)`)
+	notificationIDPattern      = regexp.MustCompile(`\bid: (not_[a-f0-9-]+)`)
+	notificationDetailsPattern = regexp.MustCompile(`\bdetails: \{\{(.*?)\}\}`)
+	notificationMessagePattern = regexp.MustCompile(`\bmessage: "(.*?)">`)
+
+	// notificationDetailKeyPattern finds the "key=" markers in a details
+	// string such as "worldId=wrld_xxx:123, worldName=Name, with comma"
+	notificationDetailKeyPattern = regexp.MustCompile(`(?:^|, )(\w+)=`)
+
+	// Matches: "[UdonBehaviour] An exception occurred during Udon execution, this UdonBehaviour will be halted."
+	udonExceptionPattern = regexp.MustCompile(
+		`\[UdonBehaviour\] An exception occurred during Udon execution`,
+	)
+
+	// Matches the object name in Udon exception entries, if logged:
+	// "... on GameObject 'Door Switch'" or "GameObject: Door Switch"
+	// Captures: (1) object name
+	udonObjectPattern = regexp.MustCompile(
+		`GameObject(?: '|: )([^'\n]+)`,
+	)
+
+	// Client information logged at startup. Each line yields an app_start
+	// event carrying one field; pkg/vrclog merges them into one event.
+	// The patterns are anchored after the level column (" -  ") so that
+	// the same words inside other messages do not match.
+	//
+	// Matches: "VRChat Build: 2024.1.1p2-1409--Release"
+	// Captures: (1) build version
+	appBuildPattern = regexp.MustCompile(
+		`\s-\s+VRChat Build: (.+)$`,
+	)
+
+	// Matches: "Unity Version: 2022.3.6f1-DWR"
+	// Captures: (1) Unity version
+	appUnityPattern = regexp.MustCompile(
+		`\s-\s+Unity Version: (.+)$`,
+	)
+
+	// Matches: "XR Device: OpenXR Display" or "XR Device: None" (desktop)
+	// Captures: (1) XR device name
+	appXRDevicePattern = regexp.MustCompile(
+		`\s-\s+XR Device: (.+)$`,
+	)
+
+	// Matches: "Command line arguments: --no-vr --profile=0"
+	// Captures: (1) arguments
+	appCommandLinePattern = regexp.MustCompile(
+		`\s-\s+Command line arguments: (.*)$`,
+	)
+
+	// Matches: "VRCApplication: OnApplicationQuit at 1234.56"
+	appQuitPattern = regexp.MustCompile(
+		`VRCApplication: OnApplicationQuit at `,
+	)
+
+	// Matches: "[String Download] Attempting to load String from URL 'https://...'"
+	// Matches: "[Image Download] Attempting to load image from URL 'https://...'"
+	// Captures: (1) kind ("String" or "Image"), (2) URL
+	remoteDownloadRequestPattern = regexp.MustCompile(
+		`\[(String|Image) Download\] Attempting to load \w+ from URL '(.+)'$`,
+	)
+
+	// Matches: "[Image Download] Successfully downloaded image from URL 'https://...'"
+	// Captures: (1) kind, (2) URL (optional)
+	remoteDownloadSuccessPattern = regexp.MustCompile(
+		`\[(String|Image) Download\] (?:Successfully (?:loaded|downloaded)|Downloaded|Loaded)\b[^']*?(?:URL '(.+)')?$`,
+	)
+
+	// Matches: "[String Download] Failed to load String from URL 'https://...': 404 Not Found"
+	// Matches: "[Image Download] Error: Image is too large"
+	// Captures: (1) kind, (2) URL (optional), (3) error text (optional)
+	remoteDownloadErrorPattern = regexp.MustCompile(
+		`\[(String|Image) Download\] (?:Failed|Error|Could not)\b[^':]*?(?:URL '(.+?)')?(?::\s*(.*))?$`,
+	)
+
+	// Matches: "[AssetBundleDownloadManager] [12] Starting download of Avatar avtr_xxx"
+	// Matches: "[AssetBundleDownloadManager] [12] Finished download of World wrld_xxx, 12845056 bytes"
+	// Matches: "[AssetBundleDownloadManager] [12] Unpacking Avatar avtr_xxx (12.3 MB)"
+	// Matches: "[AssetBundleDownloadManager] [12] Failed to load Avatar avtr_xxx: Incompatible bundle"
+	// Captures: (1) action, (2) kind, (3) asset ID, (4) size (optional),
+	//           (5) size unit (optional), (6) error text (optional)
+	assetDownloadPattern = regexp.MustCompile(
+		`\[AssetBundleDownloadManager\] (?:\[\d+\] )?(Starting download of|Finished download of|Unpacking|Failed to (?:download|load)) (Avatar|World) ((?:avtr|wrld)_[a-f0-9-]+)(?:,? \(?(\d+(?:\.\d+)?) ?(bytes|B|KB|MB|GB)\)?)?(?::\s*(.*))?$`,
+	)
+
+	// Matches: "[Behaviour] DisplayName (usr_xxx) dropped portal to wrld_xxx:12345~region(jp)"
+	// Captures: (1) display name, (2) user ID (optional), (3) world ID, (4) instance ID (optional)
+	portalDropPattern = regexp.MustCompile(
+		`\[Behaviour\] (.+?)(?: \((usr_[a-f0-9-]+)\))? dropped (?:a )?portal to (wrld_[a-f0-9-]+)(?::(\S+))?$`,
+	)
+
+	// Matches: "[Network Processing] RPC invoked ConfigurePortal on (Clone [800004] Portals/PortalInternalDynamic) for DisplayName"
+	// The target world is not logged on this line.
+	// Captures: (1) display name
+	portalConfigurePattern = regexp.MustCompile(
+		`RPC invoked ConfigurePortal on \(.+?\) for (.+)$`,
+	)
+
+	// Matches: "[StickersManager] User usr_xxx (DisplayName) spawned sticker inv_xxx"
+	// Matches: "[EmojiManager] User usr_xxx (DisplayName) spawned emoji inv_xxx"
+	// Matches: "[PrintManager] User usr_xxx (DisplayName) placed print prnt_xxx"
+	// Captures: (1) user ID, (2) display name, (3) item kind, (4) item ID (optional)
+	itemSpawnPattern = regexp.MustCompile(
+		`\[(?:Stickers|Emoji|Print)Manager\] User (usr_[a-f0-9-]+) \((.+)\) (?:spawned|placed) (sticker|emoji|print)(?: (\S+))?$`,
+	)
+
+	// Matches: "[ModerationManager] A vote kick has been initiated against DisplayName, do you agree?"
+	// Matches: "[ModerationManager] A vote kick has been initiated against DisplayName (usr_xxx) by Actor (usr_yyy), do you agree?"
+	// Captures: (1) target name, (2) target ID (optional), (3) actor name (optional), (4) actor ID (optional)
+	voteKickPattern = regexp.MustCompile(
+		`\[ModerationManager\] A vote kick has been initiated against (.+?)(?: \((usr_[a-f0-9-]+)\))?(?: by (.+?)(?: \((usr_[a-f0-9-]+)\))?)?, do you agree\?$`,
+	)
+
+	// Matches: "[ModerationManager] DisplayName has been kicked"
+	// Matches: "[ModerationManager] DisplayName (usr_xxx) has been muted by Actor (usr_yyy)."
+	// Captures: (1) target name, (2) target ID (optional), (3) action,
+	//           (4) actor name (optional), (5) actor ID (optional)
+	moderationPattern = regexp.MustCompile(
+		`\[ModerationManager\] (.+?)(?: \((usr_[a-f0-9-]+)\))? has been (kicked|banned|warned|blocked|unblocked|muted|unmuted)(?: by (.+?)(?: \((usr_[a-f0-9-]+)\))?)?\.?$`,
+	)
+
+	// Matches: "[Behaviour] Switching DisplayName to avatar Avatar Name"
+	// Matches: "[Behaviour] Switching DisplayName to avatar Avatar Name (avtr_xxx)"
+	// Captures: (1) display name, (2) avatar name, (3) avatar ID (optional)
+	avatarChangePattern = regexp.MustCompile(
+		`\[Behaviour\] Switching (.+?) to avatar (.+?)(?:\s+\((avtr_[a-f0-9-]+)\))?$`,
+	)
+
+	// Matches: "[Video Playback] Attempting to resolve URL 'https://...'"
+	// Captures: (1) requested URL
+	videoRequestPattern = regexp.MustCompile(
+		`\[Video Playback\] Attempting to resolve URL '(.+)'$`,
+	)
+
+	// Matches: "[Video Playback] URL 'https://...' resolved to 'https://...'"
+	// Captures: (1) requested URL, (2) resolved URL
+	videoResolvedPattern = regexp.MustCompile(
+		`\[Video Playback\] URL '(.+?)' resolved to '(.+)'$`,
+	)
+
+	// Matches: "[Video Playback] ERROR: Video unavailable"
+	// Captures: (1) error message
+	videoErrorPattern = regexp.MustCompile(
+		`\[Video Playback\] ERROR: (.+)$`,
+	)
+
+	// Matches: "[VRC Camera] Took screenshot to: C:\Users\...\VRChat_xxx.png"
+	// Captures: (1) file path
+	screenshotPattern = regexp.MustCompile(
+		`\[VRC Camera\] Took screenshot to: (.+)$`,
+	)
+
+	// Matches: "User Authenticated: DisplayName (usr_xxx)"
+	// Captures: (1) display name, (2) user ID
+	selfAuthenticatedPattern = regexp.MustCompile(
+		`User Authenticated: (.+?) \((usr_[a-f0-9-]+)\)$`,
+	)
+
+	// Matches: "[Behaviour] Initialized PlayerAPI "DisplayName" is local"
+	// Captures: (1) display name
+	localPlayerPattern = regexp.MustCompile(
+		`\[Behaviour\] Initialized PlayerAPI "(.+)" is local$`,
+	)
 )
 
 // exclusionPatterns are patterns that look like events but should be ignored.
 var exclusionPatterns = []string{
 	"OnPlayerJoined:",     // Different log format
-	"OnPlayerLeftRoom",    // Self leaving
 	"Joining or Creating", // Not actual join
 	"Joining friend",      // Not actual join
+	"OnPlayerLeftRoom",    // Same departure as OnLeftRoom
 }
@@ -0,0 +1,167 @@
+package parser
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/vrclog/vrclog-go/pkg/vrclog/event"
+)
+
+// Most log lines are not events. Before any regex runs, a line is split
+// into its category tag and message and checked against cheap substring
+// markers; only candidate lines are matched against the parsers for their
+// tag whose markers it contains. Nothing here allocates.
+
+// lineParser parses a header line with a known timestamp.
+type lineParser func(line string, ts time.Time) *event.Event
+
+// parserSpec describes which lines a lineParser can match, as given by its
+// patterns: the category tags they require and substrings of which the
+// message must contain at least one.
+type parserSpec struct {
+	parse   lineParser
+	tags    []string // nil if the patterns accept any tag, or none
+	markers []string // nil if every line with one of tags is a candidate
+}
+
+// accepts reports whether msg contains one of s's markers.
+func (s *parserSpec) accepts(msg string) bool {
+	return s.markers == nil || containsAny(msg, s.markers)
+}
+
+// parserSpecs lists the built-in parsers in the order they are tried.
+// Keep tags and markers in sync with the patterns in patterns.go.
+// UdonBehaviour lines are handled separately, since the parser also reads
+// the continuation lines.
+var parserSpecs = []parserSpec{
+	{parsePlayerJoin, []string{"Behaviour"}, []string{"OnPlayerJoined "}},
+	{parsePlayerLeft, []string{"Behaviour"}, []string{"OnPlayerLeft "}},
+	{parseWorldJoin, []string{"Behaviour"}, []string{"Entering Room: ", "Joining "}},
+	{parseWorldLeave, []string{"Behaviour"}, []string{"OnLeftRoom"}},
+	{parseDisconnect, nil, []string{"Disconnected", "Lost connection"}},
+	{parseAvatarChange, []string{"Behaviour"}, []string{"Switching "}},
+	{parsePortalDrop, nil, []string{" portal to ", "ConfigurePortal"}},
+	{parseSelfAuthenticated, nil, []string{"User Authenticated: "}},
+	{parseNotification, nil, []string{"Received Notification: "}},
+	{parseVideoPlay, []string{"Video Playback"}, nil},
+	{parseScreenshot, []string{"VRC Camera"}, nil},
+	{parseRemoteDownload, []string{"String Download", "Image Download"}, nil},
+	{parseAssetDownload, []string{"AssetBundleDownloadManager"}, nil},
+	{parseItemSpawn, []string{"StickersManager", "EmojiManager", "PrintManager"}, nil},
+	{parseModeration, []string{"ModerationManager"}, nil},
+	{parseAppStart, nil, []string{"VRChat Build: ", "Unity Version: ", "XR Device: ", "Command line arguments: "}},
+	{parseAppQuit, nil, []string{"OnApplicationQuit"}},
+}
+
+// tagParsers maps each tag named in parserSpecs to the specs that apply
+// to its lines, in parserSpecs order. Lines with any other tag, or none,
+// use anyTagParsers.
+var tagParsers, anyTagParsers = func() (map[string][]parserSpec, []parserSpec) {
+	byTag := make(map[string][]parserSpec)
+	var anyTag []parserSpec
+	for _, spec := range parserSpecs {
+		if spec.tags == nil {
+			anyTag = append(anyTag, spec)
+		}
+		for _, tag := range spec.tags {
+			byTag[tag] = nil
+		}
+	}
+	for tag := range byTag {
+		for _, spec := range parserSpecs {
+			if spec.tags == nil || slices.Contains(spec.tags, tag) {
+				byTag[tag] = append(byTag[tag], spec)
+			}
+		}
+	}
+	return byTag, anyTag
+}()
+
+// specsFor returns the parser specs that apply to lines with tag.
+func specsFor(tag string) []parserSpec {
+	if specs, ok := tagParsers[tag]; ok {
+		return specs
+	}
+	return anyTagParsers
+}
+
+// The local player marker is not an event but is recognized by
+// ParseLocalPlayer, so its lines are candidates as well.
+const (
+	localPlayerTag    = "Behaviour"
+	localPlayerMarker = "Initialized PlayerAPI"
+)
+
+// splitHeader splits a timestamped log line into its category tag (without
+// brackets, empty if none) and the message after it.
+// ok is false if line does not start with a timestamp.
+func splitHeader(line string) (tag, msg string, ok bool) {
+	if !hasTimestampPrefix(line) {
+		return "", "", false
+	}
+
+	// Layout after the timestamp: " Log        -  [Category] message"
+	rest := line[timestampLen:]
+	if _, after, found := strings.Cut(rest, " - "); found {
+		rest = after
+	}
+	rest = strings.TrimLeft(rest, " ")
+
+	if strings.HasPrefix(rest, "[") {
+		if end := strings.IndexByte(rest, ']'); end > 0 {
+			return rest[1:end], strings.TrimLeft(rest[end+1:], " "), true
+		}
+	}
+	return "", rest, true
+}
+
+// isCandidate reports whether a line with the given tag and message may be
+// recognized by the built-in parsers or ParseLocalPlayer.
+func isCandidate(tag, msg string) bool {
+	if tag == "UdonBehaviour" {
+		return true
+	}
+	if tag == localPlayerTag && strings.Contains(msg, localPlayerMarker) {
+		return true
+	}
+	specs := specsFor(tag)
+	for i := range specs {
+		if specs[i].accepts(msg) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// MayMatch reports whether entry may be recognized by ParseInLocation or
+// ParseLocalPlayer. It does not allocate, so callers can skip converting
+// and parsing the vast majority of entries, which are not events.
+//
+// A false result is definitive; a true result still needs parsing.
+func MayMatch(entry []byte) bool {
+	if i := bytes.IndexByte(entry, '\n'); i >= 0 {
+		entry = entry[:i]
+	}
+	entry = bytes.TrimRight(entry, "\r")
+
+	tag, msg, ok := splitHeader(unsafeString(entry))
+	return ok && isCandidate(tag, msg)
+}
+
+// unsafeString returns a string sharing b's memory. The result must not be
+// retained past the call it is passed to, or used after b is modified.
+func unsafeString(b []byte) string {
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
@@ -19,14 +19,33 @@ type Tailer struct {
 	t      *tail.Tail
 	ctx    context.Context
 	cancel context.CancelFunc
-	lines  chan string
+	lines  chan Line
 	errors chan error
 	doneCh chan struct{}
 
+	// Position tracking, owned by run
+	lastNum  int   // nxadm/tail line number of the previous line
+	counting bool  // line numbers are counted from the start of the file
+	next     int64 // offset of the next line, if known
+	hasNext  bool
+
 	mu      sync.Mutex
 	stopped bool
 }
 
+// Line is a line read from the file, without its line ending.
+type Line struct {
+	Text string
+
+	// Num is the 1-based line number in the file, or 0 if unknown. Lines
+	// are only counted when tailing started at the beginning of the file
+	// (FromStart) or after the file was reopened.
+	Num int
+
+	// Offset is the byte offset of the start of the line in the file.
+	Offset int64
+}
+
 // Config holds configuration for tailing.
 type Config struct {
 	// Follow continues reading as the file grows (tail -f).
@@ -79,12 +98,14 @@ func New(ctx context.Context, filepath string, cfg Config) (*Tailer, error) {
 	ctx, cancel := context.WithCancel(ctx)
 
 	tailer := &Tailer{
-		t:      t,
-		ctx:    ctx,
-		cancel: cancel,
-		lines:  make(chan string),
-		errors: make(chan error, tailerErrBuffer),
-		doneCh: make(chan struct{}),
+		t:        t,
+		ctx:      ctx,
+		cancel:   cancel,
+		lines:    make(chan Line),
+		errors:   make(chan error, tailerErrBuffer),
+		doneCh:   make(chan struct{}),
+		counting: cfg.FromStart,
+		hasNext:  cfg.FromStart,
 	}
 
 	go tailer.run()
@@ -93,7 +114,7 @@ func New(ctx context.Context, filepath string, cfg Config) (*Tailer, error) {
 }
 
 // Lines returns a channel that receives log lines.
-func (t *Tailer) Lines() <-chan string {
+func (t *Tailer) Lines() <-chan Line {
 	return t.lines
 }
 
@@ -145,10 +166,35 @@ func (t *Tailer) run() {
 				continue
 			}
 			select {
-			case t.lines <- line.Text:
+			case t.lines <- t.position(line):
 			case <-t.ctx.Done():
 				return
 			}
 		}
 	}
 }
+
+// position converts a line from nxadm/tail, whose SeekInfo holds the offset
+// just past the line, to a Line with its line number and start offset.
+func (t *Tailer) position(line *tail.Line) Line {
+	if line.Num <= t.lastNum {
+		// nxadm/tail restarts counting when it reopens the file, which is
+		// then read from the start
+		t.counting = true
+		t.next, t.hasNext = 0, true
+	}
+	t.lastNum = line.Num
+
+	l := Line{Text: line.Text, Offset: t.next}
+	if t.counting {
+		l.Num = line.Num
+	}
+	if !t.hasNext {
+		// First line after seeking to the end: assume a "\n" line ending.
+		// Later lines start where the previous one ended, which also holds
+		// for a line sent at EOF before its line ending was written.
+		l.Offset = max(line.SeekInfo.Offset-int64(len(line.Text))-1, 0)
+	}
+	t.next, t.hasNext = line.SeekInfo.Offset, true
+	return l
+}
@@ -39,8 +39,8 @@ func TestTailer_NewLines(t *testing.T) {
 	// Verify reception
 	select {
 	case line := <-tailer.Lines():
-		if line != "line1" {
-			t.Errorf("got %q, want %q", line, "line1")
+		if line.Text != "line1" {
+			t.Errorf("got %q, want %q", line.Text, "line1")
 		}
 	case <-time.After(2 * time.Second):
 		t.Error("timeout waiting for line")
@@ -75,11 +75,13 @@ func TestTailer_MultipleLines(t *testing.T) {
 		f.WriteString(line + "\n")
 		f.Sync()
 
-		// Verify each line is received in order
+		// Verify each line is received in order. Lines are not numbered
+		// when tailing starts at the end of the file.
+		want := Line{Text: line, Offset: int64(i * len("lineN\n"))}
 		select {
 		case got := <-tailer.Lines():
-			if got != line {
-				t.Errorf("line %d: got %q, want %q", i, got, line)
+			if got != want {
+				t.Errorf("line %d: got %+v, want %+v", i, got, want)
 			}
 		case <-time.After(2 * time.Second):
 			t.Errorf("timeout waiting for line %d: %q", i, line)
@@ -92,7 +94,7 @@ func TestTailer_FromStart(t *testing.T) {
 	logFile := filepath.Join(dir, "test.log")
 
 	// Create file with existing content
-	if err := os.WriteFile(logFile, []byte("existing1\nexisting2\n"), 0644); err != nil {
+	if err := os.WriteFile(logFile, []byte("existing1\r\nexisting2\n"), 0644); err != nil {
 		t.Fatal(err)
 	}
 
@@ -108,16 +110,19 @@ func TestTailer_FromStart(t *testing.T) {
 	}
 	defer tailer.Stop()
 
-	// Should receive existing lines
-	expected := []string{"existing1", "existing2"}
+	// Should receive existing lines, numbered from the start of the file
+	expected := []Line{
+		{Text: "existing1\r", Num: 1, Offset: 0},
+		{Text: "existing2", Num: 2, Offset: 11},
+	}
 	for _, want := range expected {
 		select {
 		case got := <-tailer.Lines():
 			if got != want {
-				t.Errorf("got %q, want %q", got, want)
+				t.Errorf("got %+v, want %+v", got, want)
 			}
 		case <-time.After(2 * time.Second):
-			t.Errorf("timeout waiting for line %q", want)
+			t.Errorf("timeout waiting for line %q", want.Text)
 		}
 	}
 }
@@ -25,13 +25,13 @@
 //	        if !ok {
 //	            return
 //	        }
-//	        switch event.Type {
-//	        case vrclog.EventPlayerJoin:
-//	            fmt.Printf("%s joined\n", event.PlayerName)
-//	        case vrclog.EventPlayerLeft:
-//	            fmt.Printf("%s left\n", event.PlayerName)
-//	        case vrclog.EventWorldJoin:
-//	            fmt.Printf("Joined world: %s\n", event.WorldName)
+//	        switch d := event.Data.(type) {
+//	        case vrclog.PlayerJoinData:
+//	            fmt.Printf("%s joined\n", d.PlayerName)
+//	        case vrclog.PlayerLeftData:
+//	            fmt.Printf("%s left\n", d.PlayerName)
+//	        case vrclog.WorldJoinData:
+//	            fmt.Printf("Joined world: %s\n", d.WorldName)
 //	        }
 //	    case err, ok := <-errs:
 //	        if !ok {
@@ -0,0 +1,94 @@
+package vrclog
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/vrclog/vrclog-go/internal/parser"
+)
+
+// DriftReport summarizes [Behaviour] log lines that no parser recognized,
+// grouped by shape (the message with names, IDs, numbers and URLs
+// replaced by placeholders). A sudden new shape with a high count after
+// a VRChat update usually means a log format changed and events are being
+// missed.
+//
+// Pass a DriftReport to WithParseDriftReport or WithDirDriftReport; it is
+// filled in while iterating and should be read after iteration ends.
+// The zero value is ready to use. A DriftReport is not safe for concurrent use.
+type DriftReport struct {
+	// Entries is the number of log entries read.
+	Entries int
+
+	// Unrecognized is the number of [Behaviour] entries no parser matched.
+	Unrecognized int
+
+	// Shapes counts unrecognized entries by shape.
+	Shapes map[string]int
+
+	// Examples holds the first raw entry seen for each shape.
+	Examples map[string]string
+}
+
+// DriftShape is one line shape in a DriftReport.
+type DriftShape struct {
+	Shape   string
+	Count   int
+	Example string
+}
+
+// Top returns the n most frequent shapes, most frequent first, with ties
+// ordered by shape. If n <= 0, all shapes are returned.
+func (r *DriftReport) Top(n int) []DriftShape {
+	shapes := make([]DriftShape, 0, len(r.Shapes))
+	for shape, count := range r.Shapes {
+		shapes = append(shapes, DriftShape{Shape: shape, Count: count, Example: r.Examples[shape]})
+	}
+	slices.SortFunc(shapes, func(a, b DriftShape) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Shape, b.Shape)
+	})
+	if n > 0 && len(shapes) > n {
+		shapes = shapes[:n]
+	}
+	return shapes
+}
+
+// add records an unrecognized event parsed from entry.
+func (r *DriftReport) add(ev *Event, entry string) {
+	if r.Shapes == nil {
+		r.Shapes = make(map[string]int)
+		r.Examples = make(map[string]string)
+	}
+	shape := ev.Data.(UnrecognizedData).Shape
+	r.Unrecognized++
+	r.Shapes[shape]++
+	if _, ok := r.Examples[shape]; !ok {
+		r.Examples[shape] = entry
+	}
+}
+
+// detectsDrift reports whether unmatched [Behaviour] entries need to be
+// looked at, for unrecognized events or the drift report.
+func (c *parseConfig) detectsDrift() bool {
+	return c.unrecognized || c.drift != nil
+}
+
+// checkDrift records an entry no parser matched in the drift report, if
+// any, and returns its unrecognized event if those are requested.
+// It returns nil if the entry is not an unrecognized [Behaviour] line.
+func (c *parseConfig) checkDrift(entry string) *Event {
+	ev := parser.ParseUnrecognized(entry, c.location)
+	if ev == nil {
+		return nil
+	}
+	if c.drift != nil {
+		c.drift.add(ev, entry)
+	}
+	if !c.unrecognized {
+		return nil
+	}
+	return ev
+}
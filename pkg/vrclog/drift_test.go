@@ -0,0 +1,93 @@
+package vrclog_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vrclog/vrclog-go/pkg/vrclog"
+)
+
+func TestParseDir_DriftReport(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := `2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined User1
+2024.01.15 12:00:01 Log        -  [Behaviour] OnPlayerJoinedNew User2 (usr_22222222-1234-1234-1234-123456789abc)
+2024.01.15 12:00:02 Log        -  [Behaviour] OnPlayerJoinedNew Other User (usr_33333333-1234-1234-1234-123456789abc)
+2024.01.15 12:00:03 Log        -  [Behaviour] Restored player 4
+2024.01.15 12:00:04 Log        -  [Network] Connected
+2024.01.15 12:00:05 Log        -  [Behaviour] Joining or Creating Room
+`
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Report only: no unrecognized events
+	var report vrclog.DriftReport
+	var events []vrclog.Event
+	for ev, err := range vrclog.ParseDir(context.Background(), vrclog.WithDirPaths(logFile), vrclog.WithDirDriftReport(&report)) {
+		if err != nil {
+			t.Fatalf("ParseDir error: %v", err)
+		}
+		events = append(events, ev)
+	}
+	if len(events) != 1 || events[0].Type != vrclog.EventPlayerJoin {
+		t.Fatalf("got %+v, want one player_join", events)
+	}
+
+	if report.Entries != 6 || report.Unrecognized != 3 {
+		t.Errorf("Entries, Unrecognized = %d, %d, want 6, 3", report.Entries, report.Unrecognized)
+	}
+	top := report.Top(0)
+	if len(top) != 2 {
+		t.Fatalf("Top(0) = %+v, want 2 shapes", top)
+	}
+	if top[0].Shape != "OnPlayerJoinedNew <name> (<id>)" || top[0].Count != 2 {
+		t.Errorf("top shape = %+v", top[0])
+	}
+	if top[0].Example != "2024.01.15 12:00:01 Log        -  [Behaviour] OnPlayerJoinedNew User2 (usr_22222222-1234-1234-1234-123456789abc)" {
+		t.Errorf("top example = %q", top[0].Example)
+	}
+	if got := report.Top(1); len(got) != 1 {
+		t.Errorf("Top(1) returned %d shapes", len(got))
+	}
+}
+
+func TestParseFile_WithUnrecognized(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := `2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined User1
+2024.01.15 12:00:01 Log        -  [Behaviour] Restored player 4
+2024.01.15 12:00:02 Log        -  [Network] Connected
+`
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := vrclog.ParseFileAll(context.Background(), logFile, vrclog.WithParseUnrecognized(true))
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	ev := events[1]
+	if d := data[vrclog.UnrecognizedData](ev); ev.Type != vrclog.EventUnrecognized || d.Message != "Restored player 4" || d.Shape != "Restored player <n>" {
+		t.Errorf("got %+v, want unrecognized event", ev)
+	}
+
+	// Unrecognized events are subject to type filters
+	events, err = vrclog.ParseFileAll(context.Background(), logFile,
+		vrclog.WithParseUnrecognized(true),
+		vrclog.WithParseExcludeTypes(vrclog.EventUnrecognized),
+	)
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("got %d events with unrecognized excluded, want 1", len(events))
+	}
+}
@@ -0,0 +1,105 @@
+package vrclog
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"github.com/vrclog/vrclog-go/internal/parser"
+)
+
+// ParseEntry parses a single VRChat log line (or multi-line entry) into a
+// generic LogEntry exposing its level, category and message.
+//
+// Return values:
+//   - (*LogEntry, nil): Successfully parsed entry
+//   - (nil, nil): Line has no timestamp (e.g. a continuation line)
+//   - (nil, error): Timestamp is present but malformed
+//
+// Example:
+//
+//	line := "2024.01.15 12:10:00 Log        -  [Network] Some network message"
+//	entry, err := vrclog.ParseEntry(line)
+//	// entry.Level == vrclog.LevelLog, entry.Category == "Network"
+func ParseEntry(line string) (*LogEntry, error) {
+	return parser.ParseEntry(line)
+}
+
+// ParseFileEntries parses a VRChat log file and returns an iterator over
+// all log entries, not only recognized events.
+//
+// It accepts the same options as ParseFile; WithParseTimeRange,
+// WithParseStopOnError, WithParseEntryLevels and WithParseEntryCategories
+// apply, event type filters do not.
+//
+// Example:
+//
+//	for entry, err := range vrclog.ParseFileEntries(ctx, "output_log.txt",
+//	    vrclog.WithParseEntryLevels(vrclog.LevelWarning, vrclog.LevelError),
+//	) {
+//	    if err != nil {
+//	        log.Printf("error: %v", err)
+//	        break
+//	    }
+//	    fmt.Printf("[%s] %s: %s\n", entry.Level, entry.Category, entry.Message)
+//	}
+func ParseFileEntries(ctx context.Context, path string, opts ...ParseOption) iter.Seq2[LogEntry, error] {
+	// Validate path upfront
+	if path == "" {
+		return func(yield func(LogEntry, error) bool) {
+			yield(LogEntry{}, errors.New("vrclog: path required"))
+		}
+	}
+
+	return parseFileEntries(ctx, path, applyParseOptions(opts))
+}
+
+// parseFileEntries is ParseFileEntries with an already-resolved configuration.
+func parseFileEntries(ctx context.Context, path string, cfg *parseConfig) iter.Seq2[LogEntry, error] {
+	return func(yield func(LogEntry, error) bool) {
+		err := readEntries(ctx, path, func(b []byte, _ linePos) bool {
+			raw := string(b)
+			entry, err := parser.ParseEntryInLocation(raw, cfg.location)
+			if err != nil {
+				if cfg.stopOnError {
+					yield(LogEntry{}, &ParseError{Line: raw, Err: err})
+					return false
+				}
+				// Skip malformed lines by default
+				return true
+			}
+			if entry == nil {
+				return true
+			}
+
+			if !cfg.entryFilter.Allows(entry) {
+				return true
+			}
+
+			// Apply time range filter
+			if !cfg.since.IsZero() && entry.Timestamp.Before(cfg.since) {
+				return true
+			}
+			if !cfg.until.IsZero() && entry.Timestamp.After(cfg.until) {
+				return false // Past the time window, stop iteration
+			}
+
+			return yield(*entry, nil)
+		})
+		if err != nil {
+			yield(LogEntry{}, err)
+		}
+	}
+}
+
+// ParseDirEntries parses all VRChat log files in a directory, yielding all
+// log entries in chronological order (by file modification time, oldest first).
+//
+// It accepts the same options as ParseDir; WithDirTimeRange, WithDirStopOnError,
+// WithDirEntryLevels and WithDirEntryCategories apply, event type filters do not.
+func ParseDirEntries(ctx context.Context, opts ...ParseDirOption) iter.Seq2[LogEntry, error] {
+	cfg := applyParseDirOptions(opts)
+	return parseDir(ctx, cfg, func(path string) iter.Seq2[LogEntry, error] {
+		return parseFileEntries(ctx, path, &cfg.parseConfig)
+	})
+}
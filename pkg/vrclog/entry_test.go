@@ -0,0 +1,220 @@
+package vrclog_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vrclog/vrclog-go/pkg/vrclog"
+)
+
+// sampleLog mirrors testdata/logs/sample.txt.
+const sampleLog = `2024.01.15 12:00:00 Log        -  [Behaviour] Entering Room: Test World Name
+2024.01.15 12:00:01 Log        -  [Behaviour] Joining wrld_12345678-1234-1234-1234-123456789abc:12345~region(us)
+2024.01.15 12:00:05 Log        -  [Behaviour] OnPlayerJoined TestUser1
+2024.01.15 12:10:00 Log        -  [Network] Some network message
+2024.01.15 12:15:00 Warning    -  [Avatar] Avatar warning message
+2024.01.15 12:16:00 Error      -  [Network] Connection failed
+  at Network.Connect ()
+`
+
+func TestParseEntry(t *testing.T) {
+	entry, err := vrclog.ParseEntry("2024.01.15 12:15:00 Warning    -  [Avatar] Avatar warning message")
+	if err != nil {
+		t.Fatalf("ParseEntry() error = %v", err)
+	}
+	if entry == nil {
+		t.Fatal("ParseEntry() = nil, want entry")
+	}
+	if entry.Level != vrclog.LevelWarning || entry.Category != "Avatar" || entry.Message != "Avatar warning message" {
+		t.Errorf("ParseEntry() = %+v", entry)
+	}
+
+	entry, err = vrclog.ParseEntry("not a log line")
+	if err != nil || entry != nil {
+		t.Errorf("ParseEntry(non-log) = (%+v, %v), want (nil, nil)", entry, err)
+	}
+}
+
+func TestParseFileEntries(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+	if err := os.WriteFile(logFile, []byte(sampleLog), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		opts         []vrclog.ParseOption
+		wantMessages []string
+	}{
+		{
+			name: "all entries",
+			wantMessages: []string{
+				"Entering Room: Test World Name",
+				"Joining wrld_12345678-1234-1234-1234-123456789abc:12345~region(us)",
+				"OnPlayerJoined TestUser1",
+				"Some network message",
+				"Avatar warning message",
+				"Connection failed\n  at Network.Connect ()",
+			},
+		},
+		{
+			name: "level filter",
+			opts: []vrclog.ParseOption{vrclog.WithParseEntryLevels(vrclog.LevelWarning, vrclog.LevelError)},
+			wantMessages: []string{
+				"Avatar warning message",
+				"Connection failed\n  at Network.Connect ()",
+			},
+		},
+		{
+			name: "category filter",
+			opts: []vrclog.ParseOption{vrclog.WithParseEntryCategories("network")},
+			wantMessages: []string{
+				"Some network message",
+				"Connection failed\n  at Network.Connect ()",
+			},
+		},
+		{
+			name: "level and category filter",
+			opts: []vrclog.ParseOption{
+				vrclog.WithParseEntryLevels(vrclog.LevelLog),
+				vrclog.WithParseEntryCategories("Network"),
+			},
+			wantMessages: []string{"Some network message"},
+		},
+		{
+			name: "time range",
+			opts: []vrclog.ParseOption{
+				vrclog.WithParseSince(time.Date(2024, 1, 15, 12, 10, 0, 0, time.Local)),
+				vrclog.WithParseUntil(time.Date(2024, 1, 15, 12, 15, 0, 0, time.Local)),
+			},
+			wantMessages: []string{"Some network message", "Avatar warning message"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for entry, err := range vrclog.ParseFileEntries(context.Background(), logFile, tt.opts...) {
+				if err != nil {
+					t.Fatalf("ParseFileEntries error: %v", err)
+				}
+				got = append(got, entry.Message)
+			}
+			if len(got) != len(tt.wantMessages) {
+				t.Fatalf("got %d entries %q, want %d", len(got), got, len(tt.wantMessages))
+			}
+			for i := range got {
+				if got[i] != tt.wantMessages[i] {
+					t.Errorf("entry %d = %q, want %q", i, got[i], tt.wantMessages[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseFileEntries_EmptyPath(t *testing.T) {
+	for _, err := range vrclog.ParseFileEntries(context.Background(), "") {
+		if err == nil {
+			t.Error("ParseFileEntries with empty path should yield an error")
+		}
+		break
+	}
+}
+
+func TestParseDirEntries(t *testing.T) {
+	dir := t.TempDir()
+	file1 := filepath.Join(dir, "output_log_2024-01-15_10-00-00.txt")
+	file2 := filepath.Join(dir, "output_log_2024-01-15_12-00-00.txt")
+
+	if err := os.WriteFile(file1, []byte("2024.01.15 10:00:00 Log        -  [Network] first\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond) // Ensure different mod times
+	if err := os.WriteFile(file2, []byte(sampleLog), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []vrclog.LogEntry
+	for entry, err := range vrclog.ParseDirEntries(context.Background(),
+		vrclog.WithDirLogDir(dir),
+		vrclog.WithDirEntryCategories("Network"),
+		vrclog.WithDirEntryLevels(vrclog.LevelLog),
+	) {
+		if err != nil {
+			t.Fatalf("ParseDirEntries error: %v", err)
+		}
+		got = append(got, entry)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d entries, want 2", len(got))
+	}
+	if got[0].Message != "first" || got[1].Message != "Some network message" {
+		t.Errorf("got messages %q, %q", got[0].Message, got[1].Message)
+	}
+}
+
+func TestWatcher_EntryHandler(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	f, err := os.Create(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	entries := make(chan vrclog.LogEntry, 10)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, errs, err := vrclog.WatchWithOptions(ctx,
+		vrclog.WithLogDir(dir),
+		vrclog.WithEntryHandler(func(e vrclog.LogEntry) { entries <- e }),
+		vrclog.WithEntryCategories("Avatar"),
+	)
+	if err != nil {
+		t.Fatalf("WatchWithOptions() error = %v", err)
+	}
+
+	// Give watcher time to start
+	time.Sleep(100 * time.Millisecond)
+
+	f.WriteString("2024.01.15 12:10:00 Log        -  [Network] Some network message\n")
+	f.WriteString("2024.01.15 12:15:00 Warning    -  [Avatar] Avatar warning message\n")
+	f.WriteString("2024.01.15 12:16:00 Log        -  [Behaviour] OnPlayerJoined TestUser\n")
+	f.Sync()
+
+	// The entry handler sees only the Avatar entry; events are unaffected
+	select {
+	case e := <-entries:
+		if e.Category != "Avatar" || e.Level != vrclog.LevelWarning {
+			t.Errorf("got entry %+v, want Avatar warning", e)
+		}
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for entry")
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Type != vrclog.EventPlayerJoin {
+			t.Errorf("got event type %v, want %v", ev.Type, vrclog.EventPlayerJoin)
+		}
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for event")
+	}
+
+	select {
+	case e := <-entries:
+		t.Errorf("unexpected entry %+v", e)
+	default:
+	}
+}
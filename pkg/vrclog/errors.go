@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/vrclog/vrclog-go/internal/logfinder"
+	"github.com/vrclog/vrclog-go/pkg/vrclog/event"
 )
 
 // Sentinel errors returned by this package.
@@ -23,6 +24,9 @@ var (
 	// ErrAlreadyWatching is returned when Watch() is called on a Watcher
 	// that is already watching.
 	ErrAlreadyWatching = errors.New("watch already in progress")
+
+	// ErrInvalidInstanceID is returned by ParseInstanceID for malformed input.
+	ErrInvalidInstanceID = event.ErrInvalidInstanceID
 )
 
 // ParseError represents an error that occurred while parsing a log line.
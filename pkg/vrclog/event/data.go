@@ -0,0 +1,423 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Data is the type-specific payload of an event, stored in Event.Data.
+// Each built-in type has its own Data type, e.g. PlayerJoinData for
+// PlayerJoin, so consumers can use a type switch:
+//
+//	switch d := ev.Data.(type) {
+//	case event.PlayerJoinData:
+//	    fmt.Println(d.PlayerName, "joined")
+//	case event.WorldJoinData:
+//	    fmt.Println("entered", d.WorldName)
+//	}
+//
+// Custom event types may define their own Data types. Payloads must
+// marshal to a JSON object, whose keys appear next to "type" in the flat
+// JSON of Event.
+type Data interface {
+	// EventType returns the event type the payload belongs to.
+	EventType() Type
+}
+
+// The fields of the payload types are declared in the same order across
+// types, which is the key order of the flat JSON.
+
+// WorldJoinData is the payload of WorldJoin events.
+type WorldJoinData struct {
+	// WorldID is the VRChat world ID (wrld_xxx format).
+	WorldID string `json:"world_id,omitempty"`
+
+	// WorldName is the display name of the world.
+	WorldName string `json:"world_name,omitempty"`
+
+	// InstanceID is the instance identifier (e.g., "12345~region(us)").
+	InstanceID string `json:"instance_id,omitempty"`
+
+	// Instance is the structured form of InstanceID. Nil if InstanceID is
+	// empty or cannot be parsed.
+	Instance *InstanceInfo `json:"instance,omitempty"`
+
+	// GroupID is the VRChat group ID (grp_xxx format) of a group
+	// instance, if any.
+	GroupID string `json:"group_id,omitempty"`
+}
+
+// GroupInstanceJoinData is the payload of GroupInstanceJoin events.
+type GroupInstanceJoinData WorldJoinData
+
+// WorldLeaveData is the payload of WorldLeave events. The fields describe
+// the world the user left, if known.
+type WorldLeaveData struct {
+	WorldID    string `json:"world_id,omitempty"`
+	WorldName  string `json:"world_name,omitempty"`
+	InstanceID string `json:"instance_id,omitempty"`
+}
+
+// PlayerJoinData is the payload of PlayerJoin events.
+type PlayerJoinData struct {
+	// PlayerName is the display name of the player.
+	PlayerName string `json:"player_name,omitempty"`
+
+	// PlayerID is the VRChat user ID (usr_xxx format, if available).
+	// For player_left lines logged without it, PlayerID is taken from the
+	// matching player_join in the same instance, if known.
+	PlayerID string `json:"player_id,omitempty"`
+
+	// ActorNumber is the player's Photon actor number in the instance, if
+	// logged. Together with the instance it identifies a player session
+	// even when display names collide.
+	ActorNumber int `json:"actor_number,omitempty"`
+
+	// IsLocal is true if the player is the local user. It is only set
+	// once the local user is known from a self_authenticated event or the
+	// local PlayerAPI line.
+	IsLocal bool `json:"is_local,omitempty"`
+}
+
+// PlayerLeftData is the payload of PlayerLeft events.
+type PlayerLeftData PlayerJoinData
+
+// SelfAuthenticatedData is the payload of SelfAuthenticated events.
+type SelfAuthenticatedData struct {
+	PlayerName string `json:"player_name,omitempty"`
+	PlayerID   string `json:"player_id,omitempty"`
+}
+
+// AvatarChangeData is the payload of AvatarChange events.
+type AvatarChangeData struct {
+	// PlayerName is the display name of the player who switched.
+	PlayerName string `json:"player_name,omitempty"`
+
+	// AvatarName is the display name of the avatar.
+	AvatarName string `json:"avatar_name,omitempty"`
+
+	// AvatarID is the VRChat avatar ID (avtr_xxx format, if available).
+	AvatarID string `json:"avatar_id,omitempty"`
+}
+
+// VideoPlayData is the payload of VideoPlay events.
+type VideoPlayData struct {
+	// VideoURL is the URL requested by a video player. Empty for
+	// resolution errors, which VRChat logs without the URL.
+	VideoURL string `json:"video_url,omitempty"`
+
+	// ResolvedURL is the direct media URL VideoURL was resolved to
+	// (resolved URLs only).
+	ResolvedURL string `json:"resolved_url,omitempty"`
+
+	// VideoError is the URL resolution error message (errors only).
+	VideoError string `json:"video_error,omitempty"`
+}
+
+// ScreenshotData is the payload of Screenshot events. The world fields
+// describe the world the user was in at the time, if known.
+type ScreenshotData struct {
+	WorldID    string `json:"world_id,omitempty"`
+	WorldName  string `json:"world_name,omitempty"`
+	InstanceID string `json:"instance_id,omitempty"`
+
+	// ScreenshotPath is the file path of the saved photo.
+	ScreenshotPath string `json:"screenshot_path,omitempty"`
+}
+
+// DisconnectData is the payload of Disconnect events.
+type DisconnectData struct {
+	// Reason is the logged reason for the disconnect, if any.
+	Reason string `json:"reason,omitempty"`
+}
+
+// NotificationData is the payload of Notification events.
+type NotificationData struct {
+	// PlayerName and PlayerID identify the sender.
+	PlayerName string `json:"player_name,omitempty"`
+	PlayerID   string `json:"player_id,omitempty"`
+
+	// For invites, WorldID, WorldName and InstanceID are filled in from
+	// Details, and Instance is the structured form of InstanceID.
+	// GroupID is the group of a group notification or group instance.
+	WorldID    string        `json:"world_id,omitempty"`
+	WorldName  string        `json:"world_name,omitempty"`
+	InstanceID string        `json:"instance_id,omitempty"`
+	Instance   *InstanceInfo `json:"instance,omitempty"`
+	GroupID    string        `json:"group_id,omitempty"`
+
+	// NotificationType is the VRChat notification type, e.g. "invite",
+	// "requestInvite" or "friendRequest".
+	NotificationType string `json:"notification_type,omitempty"`
+
+	// NotificationID is the notification ID (not_xxx format).
+	NotificationID string `json:"notification_id,omitempty"`
+
+	// Message is the message attached to the notification, if any.
+	Message string `json:"message,omitempty"`
+
+	// Details holds the notification's embedded key/value details
+	// (e.g. "worldId", "worldName" for invites).
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// GroupNotificationData is the payload of GroupNotification events.
+type GroupNotificationData NotificationData
+
+// UdonExceptionData is the payload of UdonException events.
+type UdonExceptionData struct {
+	// ObjectName is the name of the GameObject whose UdonBehaviour threw,
+	// if logged.
+	ObjectName string `json:"object_name,omitempty"`
+
+	// ExceptionMessage is the exception message.
+	ExceptionMessage string `json:"exception_message,omitempty"`
+
+	// StackTrace is the full exception output following the log line,
+	// one frame per line.
+	StackTrace string `json:"stack_trace,omitempty"`
+}
+
+// AppStartData is the payload of AppStart events.
+type AppStartData struct {
+	// BuildVersion is the VRChat client build.
+	BuildVersion string `json:"build_version,omitempty"`
+
+	// UnityVersion is the Unity engine version.
+	UnityVersion string `json:"unity_version,omitempty"`
+
+	// VRMode is "vr" or "desktop", if logged.
+	VRMode string `json:"vr_mode,omitempty"`
+
+	// CommandLine is the client's command-line arguments.
+	CommandLine string `json:"command_line,omitempty"`
+}
+
+// AppQuitData is the (empty) payload of AppQuit events.
+type AppQuitData struct{}
+
+// RemoteDownloadData is the payload of RemoteDownload events. The world
+// fields describe the world that requested the download, if known.
+type RemoteDownloadData struct {
+	WorldID    string `json:"world_id,omitempty"`
+	WorldName  string `json:"world_name,omitempty"`
+	InstanceID string `json:"instance_id,omitempty"`
+
+	// DownloadKind is "string" or "image".
+	DownloadKind string `json:"download_kind,omitempty"`
+
+	// DownloadURL is the remote URL. For failures logged without the URL,
+	// it is the last URL requested of that kind.
+	DownloadURL string `json:"download_url,omitempty"`
+
+	// DownloadStatus is "requested", "succeeded" or "failed".
+	DownloadStatus string `json:"download_status,omitempty"`
+
+	// DownloadError is the error text of a failed download.
+	DownloadError string `json:"download_error,omitempty"`
+}
+
+// AssetDownloadData is the payload of AssetDownload events.
+type AssetDownloadData struct {
+	// DownloadKind is "avatar" or "world".
+	DownloadKind string `json:"download_kind,omitempty"`
+
+	// DownloadStatus is "started", "completed", "unpacking" or "failed".
+	DownloadStatus string `json:"download_status,omitempty"`
+
+	// DownloadError is the error text of a failed download or load.
+	DownloadError string `json:"download_error,omitempty"`
+
+	// AssetID is the avatar or world ID of the asset bundle
+	// (avtr_xxx or wrld_xxx).
+	AssetID string `json:"asset_id,omitempty"`
+
+	// AssetSize is the asset bundle size in bytes, if logged.
+	AssetSize int64 `json:"asset_size,omitempty"`
+
+	// DurationMS is the time in milliseconds since the download of the
+	// same asset started (completed and failed only, if the start was
+	// seen). Log timestamps have second resolution.
+	DurationMS int64 `json:"duration_ms,omitempty"`
+}
+
+// PortalDropData is the payload of PortalDrop events. The world fields
+// describe the portal's target.
+type PortalDropData struct {
+	// PlayerName and PlayerID identify the player who dropped the portal,
+	// if logged.
+	PlayerName string `json:"player_name,omitempty"`
+	PlayerID   string `json:"player_id,omitempty"`
+
+	WorldID    string        `json:"world_id,omitempty"`
+	WorldName  string        `json:"world_name,omitempty"`
+	InstanceID string        `json:"instance_id,omitempty"`
+	Instance   *InstanceInfo `json:"instance,omitempty"`
+	GroupID    string        `json:"group_id,omitempty"`
+}
+
+// StickerSpawnData is the payload of StickerSpawn events.
+type StickerSpawnData struct {
+	// PlayerName and PlayerID identify the acting player, if logged.
+	PlayerName string `json:"player_name,omitempty"`
+	PlayerID   string `json:"player_id,omitempty"`
+
+	// ItemID is the inventory or file ID of the sticker, emoji or print
+	// (e.g. inv_xxx, prnt_xxx), if logged.
+	ItemID string `json:"item_id,omitempty"`
+}
+
+// EmojiSpawnData is the payload of EmojiSpawn events.
+type EmojiSpawnData StickerSpawnData
+
+// PrintPlaceData is the payload of PrintPlace events.
+type PrintPlaceData StickerSpawnData
+
+// ModerationData is the payload of Moderation events.
+type ModerationData struct {
+	// PlayerName and PlayerID identify the acting player, if logged.
+	PlayerName string `json:"player_name,omitempty"`
+	PlayerID   string `json:"player_id,omitempty"`
+
+	// ModerationAction is one of "vote_kick", "kick", "ban", "warn",
+	// "block", "unblock", "mute" or "unmute".
+	ModerationAction string `json:"moderation_action,omitempty"`
+
+	// TargetName is the display name of the player the action applies to.
+	TargetName string `json:"target_name,omitempty"`
+
+	// TargetID is the VRChat user ID of the target, if logged.
+	TargetID string `json:"target_id,omitempty"`
+}
+
+// UnrecognizedData is the payload of Unrecognized events.
+type UnrecognizedData struct {
+	// Message is the log message after the category tag.
+	Message string `json:"message,omitempty"`
+
+	// Shape is the normalized form of Message, with names, IDs, numbers
+	// and URLs replaced by placeholders.
+	Shape string `json:"shape,omitempty"`
+}
+
+func (WorldJoinData) EventType() Type         { return WorldJoin }
+func (GroupInstanceJoinData) EventType() Type { return GroupInstanceJoin }
+func (WorldLeaveData) EventType() Type        { return WorldLeave }
+func (PlayerJoinData) EventType() Type        { return PlayerJoin }
+func (PlayerLeftData) EventType() Type        { return PlayerLeft }
+func (SelfAuthenticatedData) EventType() Type { return SelfAuthenticated }
+func (AvatarChangeData) EventType() Type      { return AvatarChange }
+func (VideoPlayData) EventType() Type         { return VideoPlay }
+func (ScreenshotData) EventType() Type        { return Screenshot }
+func (DisconnectData) EventType() Type        { return Disconnect }
+func (NotificationData) EventType() Type      { return Notification }
+func (GroupNotificationData) EventType() Type { return GroupNotification }
+func (UdonExceptionData) EventType() Type     { return UdonException }
+func (AppStartData) EventType() Type          { return AppStart }
+func (AppQuitData) EventType() Type           { return AppQuit }
+func (RemoteDownloadData) EventType() Type    { return RemoteDownload }
+func (AssetDownloadData) EventType() Type     { return AssetDownload }
+func (PortalDropData) EventType() Type        { return PortalDrop }
+func (StickerSpawnData) EventType() Type      { return StickerSpawn }
+func (EmojiSpawnData) EventType() Type        { return EmojiSpawn }
+func (PrintPlaceData) EventType() Type        { return PrintPlace }
+func (ModerationData) EventType() Type        { return Moderation }
+func (UnrecognizedData) EventType() Type      { return Unrecognized }
+
+// allData lists a zero payload of every built-in type. Add the payload of
+// new event types here, along with allTypes.
+var allData = []Data{
+	WorldJoinData{}, GroupInstanceJoinData{}, WorldLeaveData{}, PlayerJoinData{}, PlayerLeftData{},
+	SelfAuthenticatedData{}, AvatarChangeData{}, VideoPlayData{}, ScreenshotData{}, DisconnectData{},
+	NotificationData{}, GroupNotificationData{}, UdonExceptionData{}, AppStartData{}, AppQuitData{},
+	RemoteDownloadData{}, AssetDownloadData{}, PortalDropData{}, StickerSpawnData{}, EmojiSpawnData{},
+	PrintPlaceData{}, ModerationData{}, UnrecognizedData{},
+}
+
+// dataTypes maps each built-in event type to its payload type, for
+// decoding JSON.
+var dataTypes = func() map[Type]reflect.Type {
+	m := make(map[Type]reflect.Type, len(allData))
+	for _, d := range allData {
+		m[d.EventType()] = reflect.TypeOf(d)
+	}
+	return m
+}()
+
+// decodeData decodes the payload of an event of type typ from b, a JSON
+// object. It returns nil for custom types, whose payload types are not
+// known.
+func decodeData(typ Type, b []byte) (Data, error) {
+	rt, ok := dataTypes[typ]
+	if !ok {
+		return nil, nil
+	}
+	d := reflect.New(rt)
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, d.Interface()); err != nil {
+			return nil, fmt.Errorf("decoding %s data: %w", typ, err)
+		}
+	}
+	return d.Elem().Interface().(Data), nil
+}
+
+// New returns an Event of d's type with the given timestamp and payload:
+//
+//	ev := event.New(ts, event.PlayerJoinData{PlayerName: "TestUser"})
+//	// ev.Type == event.PlayerJoin, ev.Data.(event.PlayerJoinData).PlayerName == "TestUser"
+//
+// If d is nil, New returns the zero Event.
+func New(ts time.Time, d Data) Event {
+	if d == nil {
+		return Event{}
+	}
+	return Event{Type: d.EventType(), Timestamp: ts, Data: d}
+}
+
+// TypedEvent is the typed form of an Event: the fields common to all
+// events at the top level and the payload under "data".
+//
+//	{"type":"player_join","timestamp":"...","data":{"player_name":"TestUser"}}
+//
+// Unlike the flat Event JSON, the keys under "data" depend only on the
+// type. Data is omitted from JSON if nil.
+type TypedEvent struct {
+	Type      Type      `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      Data      `json:"data,omitempty"`
+	RawLine   string    `json:"raw_line,omitempty"`
+	Source    *Source   `json:"source,omitempty"`
+}
+
+// Typed returns the typed form of e.
+func (e Event) Typed() TypedEvent {
+	return TypedEvent(e)
+}
+
+// Event returns the flat form of t.
+func (t TypedEvent) Event() Event {
+	return Event(t)
+}
+
+// UnmarshalJSON decodes a typed event, choosing the Data type from "type".
+// For custom event types, "data" is ignored.
+func (t *TypedEvent) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Type      Type            `json:"type"`
+		Timestamp time.Time       `json:"timestamp"`
+		Data      json.RawMessage `json:"data"`
+		RawLine   string          `json:"raw_line"`
+		Source    *Source         `json:"source"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	d, err := decodeData(raw.Type, raw.Data)
+	if err != nil {
+		return err
+	}
+	*t = TypedEvent{Type: raw.Type, Timestamp: raw.Timestamp, Data: d, RawLine: raw.RawLine, Source: raw.Source}
+	return nil
+}
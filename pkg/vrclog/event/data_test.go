@@ -0,0 +1,211 @@
+package event
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestData_AllTypes(t *testing.T) {
+	for _, typ := range allTypes {
+		if _, ok := dataTypes[typ]; !ok {
+			t.Errorf("built-in type %q has no payload in allData", typ)
+		}
+	}
+	if len(dataTypes) != len(allTypes) {
+		t.Errorf("%d payloads for %d built-in types", len(dataTypes), len(allTypes))
+	}
+}
+
+// TestData_ConsistentKeys checks that a JSON key has the same Go type in
+// every payload, since the flat JSON and its schema share keys across
+// event types.
+func TestData_ConsistentKeys(t *testing.T) {
+	seen := make(map[string]reflect.Type)
+	for _, d := range allData {
+		typ := reflect.TypeOf(d)
+		for i := range typ.NumField() {
+			f := typ.Field(i)
+			key, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if prev, ok := seen[key]; ok && prev != f.Type {
+				t.Errorf("%s.%s: key %q is %s, elsewhere %s", typ.Name(), f.Name, key, f.Type, prev)
+			}
+			seen[key] = f.Type
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC)
+
+	ev := New(ts, PlayerJoinData{PlayerName: "TestUser"})
+	want := Event{Type: PlayerJoin, Timestamp: ts, Data: PlayerJoinData{PlayerName: "TestUser"}}
+	if !reflect.DeepEqual(ev, want) {
+		t.Errorf("New() = %+v, want %+v", ev, want)
+	}
+
+	if ev := New(ts, nil); !reflect.DeepEqual(ev, Event{}) {
+		t.Errorf("New(nil) = %+v, want zero Event", ev)
+	}
+}
+
+func TestEvent_FlatJSON(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC)
+	tests := []struct {
+		name string
+		ev   Event
+		want string
+	}{
+		{
+			name: "player_join",
+			ev: Event{
+				Type:      PlayerJoin,
+				Timestamp: ts,
+				Data:      PlayerJoinData{PlayerName: "TestUser", PlayerID: "usr_1", ActorNumber: 3},
+				RawLine:   "raw",
+				Source:    &Source{File: "output_log.txt", Line: 2, Offset: 10, Seq: 1},
+			},
+			want: `{"schema_version":1,"type":"player_join","timestamp":"2024-01-15T23:59:59Z",` +
+				`"player_name":"TestUser","player_id":"usr_1","actor_number":3,"raw_line":"raw",` +
+				`"source":{"file":"output_log.txt","line":2,"offset":10,"seq":1}}`,
+		},
+		{
+			name: "world_join",
+			ev: Event{
+				Type:      WorldJoin,
+				Timestamp: ts,
+				Data: WorldJoinData{
+					WorldID: "wrld_1", InstanceID: "1~group(grp_1)",
+					Instance: &InstanceInfo{Name: "1", AccessType: AccessGroup, OwnerID: "grp_1"}, GroupID: "grp_1",
+				},
+			},
+			want: `{"schema_version":1,"type":"world_join","timestamp":"2024-01-15T23:59:59Z",` +
+				`"world_id":"wrld_1","instance_id":"1~group(grp_1)",` +
+				`"instance":{"name":"1","access_type":"group","owner_id":"grp_1"},"group_id":"grp_1"}`,
+		},
+		{
+			name: "app_quit",
+			ev:   Event{Type: AppQuit, Timestamp: ts, Data: AppQuitData{}},
+			want: `{"schema_version":1,"type":"app_quit","timestamp":"2024-01-15T23:59:59Z"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.ev)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal = %s\nwant %s", data, tt.want)
+			}
+
+			var got Event
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.ev) {
+				t.Errorf("Unmarshal = %+v, want %+v", got, tt.ev)
+			}
+		})
+	}
+}
+
+// customData is the payload of a custom event type.
+type customData struct {
+	Count int `json:"count"`
+}
+
+func (customData) EventType() Type { return "custom_data_test" }
+
+// notObject is a payload that does not marshal to a JSON object.
+type notObject []string
+
+func (notObject) EventType() Type { return "custom_data_test" }
+
+func TestEvent_CustomData(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC)
+
+	data, err := json.Marshal(New(ts, customData{Count: 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"schema_version":1,"type":"custom_data_test","timestamp":"2024-01-15T23:59:59Z","count":2}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s\nwant %s", data, want)
+	}
+
+	// Custom payload types are not known when decoding
+	var got Event
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if got.Type != "custom_data_test" || got.Data != nil {
+		t.Errorf("Unmarshal = %+v, want custom type without data", got)
+	}
+
+	if _, err := json.Marshal(New(ts, notObject{"x"})); err == nil {
+		t.Error("Marshal succeeded, want error for a payload that is not an object")
+	}
+}
+
+func TestEvent_UnmarshalInvalidData(t *testing.T) {
+	var ev Event
+	err := json.Unmarshal([]byte(`{"type":"player_join","timestamp":"2024-01-15T23:59:59Z","player_name":1}`), &ev)
+	if err == nil {
+		t.Error("Unmarshal succeeded, want error for mistyped field")
+	}
+}
+
+func TestTypedEvent_JSON(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC)
+	ev := Event{
+		Type:      PlayerJoin,
+		Timestamp: ts,
+		Data:      PlayerJoinData{PlayerName: "TestUser", PlayerID: "usr_1"},
+		RawLine:   "raw",
+		Source:    &Source{File: "output_log.txt", Line: 2, Offset: 10, Seq: 1},
+	}
+
+	data, err := json.Marshal(ev.Typed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"schema_version":1,"type":"player_join","timestamp":"2024-01-15T23:59:59Z",` +
+		`"data":{"player_name":"TestUser","player_id":"usr_1"},"raw_line":"raw",` +
+		`"source":{"file":"output_log.txt","line":2,"offset":10,"seq":1}}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s\nwant %s", data, want)
+	}
+
+	var typed TypedEvent
+	if err := json.Unmarshal(data, &typed); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if _, ok := typed.Data.(PlayerJoinData); !ok {
+		t.Fatalf("Data = %T, want PlayerJoinData", typed.Data)
+	}
+	if got := typed.Event(); !reflect.DeepEqual(got, ev) {
+		t.Errorf("Event() = %+v, want %+v", got, ev)
+	}
+}
+
+func TestTypedEvent_UnmarshalCustomType(t *testing.T) {
+	var typed TypedEvent
+	err := json.Unmarshal([]byte(`{"type":"custom_typed_test","timestamp":"2024-01-15T23:59:59Z","data":{"x":1}}`), &typed)
+	if err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if typed.Type != "custom_typed_test" || typed.Data != nil {
+		t.Errorf("got %+v, want custom type without data", typed)
+	}
+}
+
+func TestTypedEvent_UnmarshalInvalidData(t *testing.T) {
+	var typed TypedEvent
+	err := json.Unmarshal([]byte(`{"type":"player_join","timestamp":"2024-01-15T23:59:59Z","data":{"player_name":1}}`), &typed)
+	if err == nil {
+		t.Error("Unmarshal succeeded, want error for mistyped data")
+	}
+}
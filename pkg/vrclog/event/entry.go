@@ -0,0 +1,61 @@
+package event
+
+import (
+	"strings"
+	"time"
+)
+
+// Level is the log level column of a VRChat log line, normalized to lowercase.
+type Level string
+
+const (
+	// LevelDebug is the "Debug" log level.
+	LevelDebug Level = "debug"
+
+	// LevelLog is the "Log" log level (informational).
+	LevelLog Level = "log"
+
+	// LevelWarning is the "Warning" log level.
+	LevelWarning Level = "warning"
+
+	// LevelError is the "Error" log level.
+	LevelError Level = "error"
+
+	// LevelException is the "Exception" log level.
+	LevelException Level = "exception"
+)
+
+// ParseLevel converts a string to Level.
+// It is case-insensitive and trims leading/trailing whitespace.
+// Unknown non-empty names are accepted as-is (lowercased), since VRChat
+// may introduce new levels.
+func ParseLevel(name string) Level {
+	return Level(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// LogEntry is a generic VRChat log entry.
+// Unlike Event, every timestamped line produces a LogEntry.
+//
+// For a line such as
+//
+//	2024.01.15 12:10:00 Warning    -  [Avatar] Avatar warning message
+//
+// Level is "warning", Category is "Avatar" and Message is "Avatar warning message".
+type LogEntry struct {
+	// Timestamp is when the entry was written (local time from log).
+	Timestamp time.Time `json:"timestamp"`
+
+	// Level is the normalized log level (e.g. "log", "warning", "error").
+	Level Level `json:"level"`
+
+	// Category is the bracketed tag at the start of the message without
+	// brackets (e.g. "Behaviour", "Network"). Empty if the message has no tag.
+	Category string `json:"category,omitempty"`
+
+	// Message is the text after the category tag. For multi-line entries,
+	// continuation lines are included, separated by "\n".
+	Message string `json:"message"`
+
+	// Raw is the original log entry, including continuation lines.
+	Raw string `json:"raw"`
+}
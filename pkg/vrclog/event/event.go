@@ -5,8 +5,10 @@
 package event
 
 import (
+	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -22,25 +24,133 @@ const (
 
 	// PlayerLeft indicates another player has left the instance.
 	PlayerLeft Type = "player_left"
+
+	// AvatarChange indicates a player has switched avatars.
+	AvatarChange Type = "avatar_change"
+
+	// VideoPlay indicates a video player URL was requested, resolved or
+	// failed to resolve.
+	VideoPlay Type = "video_play"
+
+	// Screenshot indicates a photo was taken with the VRChat camera.
+	Screenshot Type = "screenshot"
+
+	// SelfAuthenticated indicates the local user has logged in.
+	SelfAuthenticated Type = "self_authenticated"
+
+	// WorldLeave indicates the user has left the current world/instance.
+	WorldLeave Type = "world_leave"
+
+	// Disconnect indicates the connection to the VRChat servers was lost.
+	Disconnect Type = "disconnect"
+
+	// Notification indicates the user received a notification such as an
+	// invite, request-invite or friend request.
+	Notification Type = "notification"
+
+	// UdonException indicates an exception halted an UdonBehaviour.
+	UdonException Type = "udon_exception"
+
+	// AppStart indicates the VRChat client started. It carries the client
+	// information logged at the start of each log file.
+	AppStart Type = "app_start"
+
+	// AppQuit indicates the VRChat client is shutting down cleanly.
+	AppQuit Type = "app_quit"
+
+	// RemoteDownload indicates a world requested a remote string or image,
+	// or such a download finished or failed.
+	RemoteDownload Type = "remote_download"
+
+	// PortalDrop indicates a player dropped a portal in the instance.
+	PortalDrop Type = "portal_drop"
+
+	// StickerSpawn indicates a player placed a sticker.
+	StickerSpawn Type = "sticker_spawn"
+
+	// EmojiSpawn indicates a player spawned an emoji.
+	EmojiSpawn Type = "emoji_spawn"
+
+	// PrintPlace indicates a player placed a print (a shared photo).
+	PrintPlace Type = "print_place"
+
+	// Moderation indicates a vote-kick, kick, block, mute or similar
+	// moderation action.
+	Moderation Type = "moderation"
+
+	// GroupInstanceJoin indicates the user has joined a group instance.
+	// It is emitted right after the world_join it was derived from.
+	GroupInstanceJoin Type = "group_instance_join"
+
+	// GroupNotification indicates the user received a group notification
+	// (e.g. an announcement or group invite).
+	GroupNotification Type = "group_notification"
+
+	// AssetDownload indicates an avatar or world asset bundle download
+	// started, finished, is being unpacked, or failed to download or load.
+	AssetDownload Type = "asset_download"
+
+	// Unrecognized indicates a [Behaviour] line that no parser matched.
+	// It is only emitted when requested (e.g. WithUnrecognized), to detect
+	// log format changes.
+	Unrecognized Type = "unrecognized"
 )
 
-// allTypes is the canonical list of all event types.
-// Add new event types here when extending the parser.
-var allTypes = []Type{WorldJoin, PlayerJoin, PlayerLeft}
+// allTypes is the canonical list of all built-in event types.
+// Add new event types here when extending the parser, and bump SchemaVersion.
+var allTypes = []Type{
+	WorldJoin,
+	PlayerJoin,
+	PlayerLeft,
+	AvatarChange,
+	VideoPlay,
+	Screenshot,
+	SelfAuthenticated,
+	WorldLeave,
+	Disconnect,
+	Notification,
+	UdonException,
+	AppStart,
+	AppQuit,
+	RemoteDownload,
+	PortalDrop,
+	StickerSpawn,
+	EmojiSpawn,
+	PrintPlace,
+	Moderation,
+	GroupInstanceJoin,
+	GroupNotification,
+	AssetDownload,
+	Unrecognized,
+}
+
+// Custom type registry. Types added via Register are tracked separately
+// from allTypes and guarded by typesMu, since registration may happen
+// at any time (typically from package init of a plugin).
+var (
+	typesMu     sync.RWMutex
+	customTypes []Type
+)
 
-// TypeNames returns a sorted list of all valid event type names.
+// TypeNames returns a sorted list of all valid event type names,
+// including custom types added with Register.
 // This is the single source of truth for event type enumeration.
 func TypeNames() []string {
-	names := make([]string, len(allTypes))
-	for i, t := range allTypes {
-		names[i] = string(t)
+	typesMu.RLock()
+	names := make([]string, 0, len(allTypes)+len(customTypes))
+	for _, t := range allTypes {
+		names = append(names, string(t))
+	}
+	for _, t := range customTypes {
+		names = append(names, string(t))
 	}
+	typesMu.RUnlock()
 	sort.Strings(names)
 	return names
 }
 
 // typeByName maps lowercase string names to Type for efficient lookup.
-// Built once from allTypes at package initialization.
+// Built from allTypes at package initialization; Register adds custom types.
 var typeByName = func() map[string]Type {
 	m := make(map[string]Type, len(allTypes))
 	for _, t := range allTypes {
@@ -54,33 +164,99 @@ var typeByName = func() map[string]Type {
 // Returns the type and true if found, zero value and false otherwise.
 func ParseType(name string) (Type, bool) {
 	name = strings.ToLower(strings.TrimSpace(name))
+	typesMu.RLock()
 	t, ok := typeByName[name]
+	typesMu.RUnlock()
 	return t, ok
 }
 
+// Register adds a custom event type so that TypeNames and ParseType
+// recognize it. Names are normalized to lowercase and must consist of
+// lowercase letters, digits and underscores (e.g. "portal_spawn").
+//
+// Registering a name that is already known (built-in or custom) is a no-op
+// and returns the existing Type. Register is safe for concurrent use.
+func Register(name string) (Type, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !validTypeName(name) {
+		return "", fmt.Errorf("invalid event type name %q: must match [a-z0-9_]+", name)
+	}
+
+	typesMu.Lock()
+	defer typesMu.Unlock()
+
+	if t, ok := typeByName[name]; ok {
+		return t, nil
+	}
+	t := Type(name)
+	customTypes = append(customTypes, t)
+	typeByName[name] = t
+	return t, nil
+}
+
+// MustRegister is like Register but panics if the name is invalid.
+// It is intended for package-level variable initialization.
+func MustRegister(name string) Type {
+	t, err := Register(name)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// validTypeName reports whether name is a valid (already normalized) type name.
+func validTypeName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
 // Event represents a parsed VRChat log event.
+//
+// The fields that depend on the type are in Data, e.g. a PlayerJoinData
+// for PlayerJoin events. Event marshals to flat JSON, with the fields of
+// Data next to "type" and "timestamp"; see TypedEvent for the form with
+// the payload under "data".
 type Event struct {
 	// Type is the event type.
-	Type Type `json:"type"`
+	Type Type
 
 	// Timestamp is when the event occurred (local time from log).
-	Timestamp time.Time `json:"timestamp"`
+	Timestamp time.Time
 
-	// PlayerName is the display name of the player (for player events).
-	PlayerName string `json:"player_name,omitempty"`
+	// Data is the type-specific payload. Built-in events always carry the
+	// payload type of their Type; it is nil for custom types without one.
+	Data Data
 
-	// PlayerID is the VRChat user ID (usr_xxx format, if available).
-	PlayerID string `json:"player_id,omitempty"`
+	// RawLine is the original log line (only included if requested).
+	RawLine string
 
-	// WorldID is the VRChat world ID (wrld_xxx format).
-	WorldID string `json:"world_id,omitempty"`
+	// Source is where the event was read from (only included if requested).
+	Source *Source
+}
 
-	// WorldName is the display name of the world.
-	WorldName string `json:"world_name,omitempty"`
+// Source locates the log entry an event was parsed from, so that the
+// event can be traced back to the log.
+type Source struct {
+	// File is the path of the log file.
+	File string `json:"file"`
 
-	// InstanceID is the instance identifier (e.g., "12345~region(us)").
-	InstanceID string `json:"instance_id,omitempty"`
+	// Line is the 1-based line number of the entry's first line, or 0 if
+	// unknown (e.g. when a watcher started tailing mid-file).
+	Line int `json:"line,omitempty"`
 
-	// RawLine is the original log line (only included if requested).
-	RawLine string `json:"raw_line,omitempty"`
+	// Offset is the byte offset of the entry's first line in the file.
+	Offset int64 `json:"offset"`
+
+	// Seq is the 1-based position of the event in its stream: one
+	// ParseFile or ParseDir iteration, or one Watcher. Events derived
+	// from the same entry share File, Line and Offset but not Seq.
+	Seq uint64 `json:"seq"`
 }
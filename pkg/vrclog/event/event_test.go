@@ -1,6 +1,9 @@
 package event
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
 
 func TestParseType(t *testing.T) {
 	tests := []struct {
@@ -13,6 +16,26 @@ func TestParseType(t *testing.T) {
 		{"world_join exact", "world_join", WorldJoin, true},
 		{"player_join exact", "player_join", PlayerJoin, true},
 		{"player_left exact", "player_left", PlayerLeft, true},
+		{"avatar_change exact", "avatar_change", AvatarChange, true},
+		{"video_play exact", "video_play", VideoPlay, true},
+		{"screenshot exact", "screenshot", Screenshot, true},
+		{"self_authenticated exact", "self_authenticated", SelfAuthenticated, true},
+		{"world_leave exact", "world_leave", WorldLeave, true},
+		{"disconnect exact", "disconnect", Disconnect, true},
+		{"notification exact", "notification", Notification, true},
+		{"udon_exception exact", "udon_exception", UdonException, true},
+		{"app_start exact", "app_start", AppStart, true},
+		{"app_quit exact", "app_quit", AppQuit, true},
+		{"remote_download exact", "remote_download", RemoteDownload, true},
+		{"asset_download exact", "asset_download", AssetDownload, true},
+		{"unrecognized exact", "unrecognized", Unrecognized, true},
+		{"portal_drop exact", "portal_drop", PortalDrop, true},
+		{"sticker_spawn exact", "sticker_spawn", StickerSpawn, true},
+		{"emoji_spawn exact", "emoji_spawn", EmojiSpawn, true},
+		{"print_place exact", "print_place", PrintPlace, true},
+		{"moderation exact", "moderation", Moderation, true},
+		{"group_instance_join exact", "group_instance_join", GroupInstanceJoin, true},
+		{"group_notification exact", "group_notification", GroupNotification, true},
 
 		// Case-insensitive
 		{"uppercase WORLD_JOIN", "WORLD_JOIN", WorldJoin, true},
@@ -80,3 +103,102 @@ func TestTypeNames_Sorted(t *testing.T) {
 		}
 	}
 }
+
+func TestRegister(t *testing.T) {
+	cleanupRegistry(t)
+
+	got, err := Register(" Test_Custom_Type ")
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if got != Type("test_custom_type") {
+		t.Errorf("Register() = %q, want %q", got, "test_custom_type")
+	}
+
+	// Registered types are visible through ParseType and TypeNames
+	if pt, ok := ParseType("TEST_CUSTOM_TYPE"); !ok || pt != got {
+		t.Errorf("ParseType() = (%q, %v), want (%q, true)", pt, ok, got)
+	}
+	found := false
+	for _, name := range TypeNames() {
+		if name == "test_custom_type" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("TypeNames() missing registered type")
+	}
+
+	// Re-registering is a no-op
+	again, err := Register("test_custom_type")
+	if err != nil || again != got {
+		t.Errorf("Register() again = (%q, %v), want (%q, nil)", again, err, got)
+	}
+	count := 0
+	for _, name := range TypeNames() {
+		if name == "test_custom_type" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("TypeNames() contains %d copies of registered type, want 1", count)
+	}
+
+	// Built-in names resolve to the built-in type
+	if bt, err := Register("world_join"); err != nil || bt != WorldJoin {
+		t.Errorf("Register(world_join) = (%q, %v), want (%q, nil)", bt, err, WorldJoin)
+	}
+}
+
+func TestRegister_Invalid(t *testing.T) {
+	cleanupRegistry(t)
+
+	invalid := []string{"", "   ", "with space", "comma,separated", "dash-name", "日本語"}
+	for _, name := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Register(name); err == nil {
+				t.Errorf("Register(%q) error = nil, want error", name)
+			}
+		})
+	}
+}
+
+func TestMustRegister_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustRegister() did not panic for invalid name")
+		}
+	}()
+	MustRegister("not valid")
+}
+
+// cleanupRegistry removes the custom types registered during the test, so
+// that registrations do not leak into other tests.
+func cleanupRegistry(t *testing.T) {
+	t.Helper()
+	typesMu.RLock()
+	n := len(customTypes)
+	typesMu.RUnlock()
+
+	t.Cleanup(func() {
+		typesMu.Lock()
+		defer typesMu.Unlock()
+		for _, typ := range customTypes[n:] {
+			delete(typeByName, string(typ))
+		}
+		customTypes = customTypes[:n]
+	})
+}
+
+func TestCleanupRegistry(t *testing.T) {
+	t.Run("register", func(t *testing.T) {
+		cleanupRegistry(t)
+		MustRegister("cleanup_registry_test")
+	})
+	if _, ok := ParseType("cleanup_registry_test"); ok {
+		t.Error("type registered in a subtest is still known after cleanup")
+	}
+	if slices.Contains(TypeNames(), "cleanup_registry_test") {
+		t.Error("TypeNames() still lists the type after cleanup")
+	}
+}
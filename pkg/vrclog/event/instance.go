@@ -0,0 +1,193 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AccessType describes who can join an instance.
+type AccessType string
+
+const (
+	// AccessPublic is a public instance (no access tag).
+	AccessPublic AccessType = "public"
+
+	// AccessFriendsPlus is a Friends+ instance ("~hidden(usr_xxx)").
+	AccessFriendsPlus AccessType = "friends+"
+
+	// AccessFriends is a Friends instance ("~friends(usr_xxx)").
+	AccessFriends AccessType = "friends"
+
+	// AccessInvite is an Invite instance ("~private(usr_xxx)").
+	AccessInvite AccessType = "invite"
+
+	// AccessInvitePlus is an Invite+ instance
+	// ("~private(usr_xxx)~canRequestInvite").
+	AccessInvitePlus AccessType = "invite+"
+
+	// AccessGroup is a group instance ("~group(grp_xxx)").
+	AccessGroup AccessType = "group"
+)
+
+// InstanceInfo is the structured form of a VRChat instance ID such as
+// "12345~private(usr_xxx)~canRequestInvite~region(jp)~nonce(xxx)".
+type InstanceInfo struct {
+	// Name is the instance name or number (the part before the first "~").
+	Name string `json:"name"`
+
+	// AccessType is the instance access type derived from the tags.
+	AccessType AccessType `json:"access_type"`
+
+	// OwnerID is the instance owner: a user ID (usr_xxx) for friends/invite
+	// instances or a group ID (grp_xxx) for group instances.
+	OwnerID string `json:"owner_id,omitempty"`
+
+	// GroupAccessType is the group access type for group instances
+	// (e.g. "public", "plus", "members").
+	GroupAccessType string `json:"group_access_type,omitempty"`
+
+	// Region is the instance region (e.g. "us", "use", "eu", "jp").
+	Region string `json:"region,omitempty"`
+
+	// Nonce is the instance nonce, if present.
+	Nonce string `json:"nonce,omitempty"`
+
+	// CanRequestInvite is true if the "~canRequestInvite" tag is present.
+	CanRequestInvite bool `json:"can_request_invite,omitempty"`
+
+	// Strict is true if the "~strict" tag is present.
+	Strict bool `json:"strict,omitempty"`
+
+	// Extra holds unrecognized tags (without the leading "~") in their
+	// original order, so that String can reproduce them.
+	Extra []string `json:"extra,omitempty"`
+}
+
+// ErrInvalidInstanceID is returned by ParseInstanceID for malformed input.
+var ErrInvalidInstanceID = errors.New("invalid instance ID")
+
+// ParseInstanceID parses a VRChat instance ID into an InstanceInfo.
+// A leading "wrld_xxx:" location prefix is accepted and ignored.
+//
+// Example:
+//
+//	info, err := event.ParseInstanceID("12345~hidden(usr_xxx)~region(eu)")
+//	// info.Name == "12345", info.AccessType == event.AccessFriendsPlus
+func ParseInstanceID(s string) (InstanceInfo, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "wrld_") {
+		if _, rest, ok := strings.Cut(s, ":"); ok {
+			s = rest
+		}
+	}
+
+	parts := strings.Split(s, "~")
+	info := InstanceInfo{Name: parts[0]}
+	if info.Name == "" {
+		return InstanceInfo{}, fmt.Errorf("%w: missing instance name in %q", ErrInvalidInstanceID, s)
+	}
+
+	var private bool
+	for _, part := range parts[1:] {
+		key, value, hasValue, err := splitTag(part)
+		if err != nil {
+			return InstanceInfo{}, fmt.Errorf("%w: %v in %q", ErrInvalidInstanceID, err, s)
+		}
+
+		switch {
+		case key == "hidden" && hasValue:
+			info.AccessType, info.OwnerID = AccessFriendsPlus, value
+		case key == "friends" && hasValue:
+			info.AccessType, info.OwnerID = AccessFriends, value
+		case key == "private" && hasValue:
+			private = true
+			info.AccessType, info.OwnerID = AccessInvite, value
+		case key == "group" && hasValue:
+			info.AccessType, info.OwnerID = AccessGroup, value
+		case key == "groupAccessType" && hasValue:
+			info.GroupAccessType = value
+		case key == "region" && hasValue:
+			info.Region = value
+		case key == "nonce" && hasValue:
+			info.Nonce = value
+		case key == "canRequestInvite" && !hasValue:
+			info.CanRequestInvite = true
+		case key == "strict" && !hasValue:
+			info.Strict = true
+		default:
+			info.Extra = append(info.Extra, part)
+		}
+	}
+
+	switch {
+	case info.AccessType == "":
+		info.AccessType = AccessPublic
+	case private && info.CanRequestInvite:
+		info.AccessType = AccessInvitePlus
+	}
+
+	return info, nil
+}
+
+// splitTag splits "key(value)" into its parts. Tags without parentheses
+// (e.g. "strict") are returned with hasValue == false.
+func splitTag(tag string) (key, value string, hasValue bool, err error) {
+	open := strings.IndexByte(tag, '(')
+	if open < 0 {
+		if tag == "" || strings.ContainsRune(tag, ')') {
+			return "", "", false, fmt.Errorf("malformed tag %q", tag)
+		}
+		return tag, "", false, nil
+	}
+	if open == 0 || !strings.HasSuffix(tag, ")") {
+		return "", "", false, fmt.Errorf("malformed tag %q", tag)
+	}
+	return tag[:open], tag[open+1 : len(tag)-1], true, nil
+}
+
+// String builds the instance ID from its parts.
+// Tags are written in VRChat's canonical order, followed by Extra tags.
+func (i InstanceInfo) String() string {
+	var b strings.Builder
+	b.WriteString(i.Name)
+
+	writeTag := func(key, value string) {
+		b.WriteByte('~')
+		b.WriteString(key)
+		b.WriteByte('(')
+		b.WriteString(value)
+		b.WriteByte(')')
+	}
+
+	switch i.AccessType {
+	case AccessFriendsPlus:
+		writeTag("hidden", i.OwnerID)
+	case AccessFriends:
+		writeTag("friends", i.OwnerID)
+	case AccessInvite, AccessInvitePlus:
+		writeTag("private", i.OwnerID)
+	case AccessGroup:
+		writeTag("group", i.OwnerID)
+	}
+	if i.GroupAccessType != "" {
+		writeTag("groupAccessType", i.GroupAccessType)
+	}
+	if i.CanRequestInvite || i.AccessType == AccessInvitePlus {
+		b.WriteString("~canRequestInvite")
+	}
+	if i.Region != "" {
+		writeTag("region", i.Region)
+	}
+	if i.Nonce != "" {
+		writeTag("nonce", i.Nonce)
+	}
+	if i.Strict {
+		b.WriteString("~strict")
+	}
+	for _, tag := range i.Extra {
+		b.WriteByte('~')
+		b.WriteString(tag)
+	}
+	return b.String()
+}
@@ -0,0 +1,141 @@
+package event
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseInstanceID(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  InstanceInfo
+	}{
+		{
+			name:  "public with region",
+			input: "12345~region(us)",
+			want:  InstanceInfo{Name: "12345", AccessType: AccessPublic, Region: "us"},
+		},
+		{
+			name:  "public without tags",
+			input: "12345",
+			want:  InstanceInfo{Name: "12345", AccessType: AccessPublic},
+		},
+		{
+			name:  "friends+",
+			input: "12345~hidden(usr_abc)~region(eu)~nonce(n1)",
+			want: InstanceInfo{
+				Name: "12345", AccessType: AccessFriendsPlus, OwnerID: "usr_abc",
+				Region: "eu", Nonce: "n1",
+			},
+		},
+		{
+			name:  "friends",
+			input: "12345~friends(usr_abc)~region(jp)",
+			want:  InstanceInfo{Name: "12345", AccessType: AccessFriends, OwnerID: "usr_abc", Region: "jp"},
+		},
+		{
+			name:  "invite",
+			input: "12345~private(usr_abc)~region(use)",
+			want:  InstanceInfo{Name: "12345", AccessType: AccessInvite, OwnerID: "usr_abc", Region: "use"},
+		},
+		{
+			name:  "invite+",
+			input: "12345~private(usr_abc)~canRequestInvite~region(use)",
+			want: InstanceInfo{
+				Name: "12345", AccessType: AccessInvitePlus, OwnerID: "usr_abc",
+				Region: "use", CanRequestInvite: true,
+			},
+		},
+		{
+			name:  "group",
+			input: "12345~group(grp_abc)~groupAccessType(plus)~region(jp)",
+			want: InstanceInfo{
+				Name: "12345", AccessType: AccessGroup, OwnerID: "grp_abc",
+				GroupAccessType: "plus", Region: "jp",
+			},
+		},
+		{
+			name:  "strict and unknown tags",
+			input: "MyRoom~hidden(usr_abc)~region(us)~strict~ageGate~future(x)",
+			want: InstanceInfo{
+				Name: "MyRoom", AccessType: AccessFriendsPlus, OwnerID: "usr_abc",
+				Region: "us", Strict: true, Extra: []string{"ageGate", "future(x)"},
+			},
+		},
+		{
+			name:  "world location prefix",
+			input: "wrld_12345678-1234-1234-1234-123456789abc:12345~region(us)",
+			want:  InstanceInfo{Name: "12345", AccessType: AccessPublic, Region: "us"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInstanceID(tt.input)
+			if err != nil {
+				t.Fatalf("ParseInstanceID(%q) error = %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseInstanceID(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInstanceID_Invalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"~region(us)",
+		"12345~region(us",
+		"12345~(us)",
+		"12345~~region(us)",
+		"12345~region)us(",
+	}
+	for _, input := range invalid {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseInstanceID(input)
+			if !errors.Is(err, ErrInvalidInstanceID) {
+				t.Errorf("ParseInstanceID(%q) error = %v, want ErrInvalidInstanceID", input, err)
+			}
+		})
+	}
+}
+
+func TestInstanceInfo_String_RoundTrip(t *testing.T) {
+	ids := []string{
+		"12345",
+		"12345~region(us)",
+		"12345~hidden(usr_abc)~region(eu)~nonce(n1)",
+		"12345~friends(usr_abc)~region(jp)~nonce(n1)",
+		"12345~private(usr_abc)~region(use)~nonce(n1)",
+		"12345~private(usr_abc)~canRequestInvite~region(use)~nonce(n1)",
+		"12345~group(grp_abc)~groupAccessType(members)~region(jp)",
+		"12345~hidden(usr_abc)~region(us)~strict~ageGate",
+	}
+	for _, id := range ids {
+		t.Run(id, func(t *testing.T) {
+			info, err := ParseInstanceID(id)
+			if err != nil {
+				t.Fatalf("ParseInstanceID(%q) error = %v", id, err)
+			}
+			if got := info.String(); got != id {
+				t.Errorf("String() = %q, want %q", got, id)
+			}
+		})
+	}
+}
+
+func TestInstanceInfo_String_Builder(t *testing.T) {
+	info := InstanceInfo{
+		Name:       "99999",
+		AccessType: AccessInvitePlus,
+		OwnerID:    "usr_abc",
+		Region:     "jp",
+	}
+	want := "99999~private(usr_abc)~canRequestInvite~region(jp)"
+	if got := info.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,245 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is the version of the JSON wire format of Event and
+// TypedEvent. Both are marshaled with a "schema_version" field holding it.
+//
+// The version is bumped whenever a JSON field is added, removed, renamed
+// or changes type, or the set of built-in event types changes.
+const SchemaVersion = 1
+
+// eventHead and eventTail are the fields of the flat JSON of Event before
+// and after the fields of Data.
+type (
+	eventHead struct {
+		SchemaVersion int       `json:"schema_version"`
+		Type          Type      `json:"type"`
+		Timestamp     time.Time `json:"timestamp"`
+	}
+	eventTail struct {
+		RawLine string  `json:"raw_line,omitempty"`
+		Source  *Source `json:"source,omitempty"`
+	}
+)
+
+// MarshalJSON encodes e as flat JSON: a leading "schema_version", "type"
+// and "timestamp", the fields of e.Data, and "raw_line" and "source".
+// This is the format of earlier versions, in which the payload fields
+// were fields of Event.
+func (e Event) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(eventHead{SchemaVersion, e.Type, e.Timestamp})
+	if err != nil {
+		return nil, err
+	}
+	if e.Data != nil {
+		data, err := json.Marshal(e.Data)
+		if err != nil {
+			return nil, err
+		}
+		if b, err = appendFields(b, data); err != nil {
+			return nil, fmt.Errorf("event: %s data: %w", e.Type, err)
+		}
+	}
+	tail, err := json.Marshal(eventTail{e.RawLine, e.Source})
+	if err != nil {
+		return nil, err
+	}
+	return appendFields(b, tail)
+}
+
+// appendFields appends the fields of the JSON object obj to the JSON
+// object b.
+func appendFields(b, obj []byte) ([]byte, error) {
+	if len(obj) < 2 || obj[0] != '{' || obj[len(obj)-1] != '}' {
+		return nil, fmt.Errorf("%s is not a JSON object", obj)
+	}
+	fields := obj[1 : len(obj)-1]
+	if len(fields) == 0 {
+		return b, nil
+	}
+	b = b[:len(b)-1] // drop '}'
+	if b[len(b)-1] != '{' {
+		b = append(b, ',')
+	}
+	b = append(b, fields...)
+	return append(b, '}'), nil
+}
+
+// UnmarshalJSON decodes the flat JSON of an event, choosing the Data type
+// from "type". For custom event types, the payload fields are ignored.
+func (e *Event) UnmarshalJSON(b []byte) error {
+	var head struct {
+		Type      Type      `json:"type"`
+		Timestamp time.Time `json:"timestamp"`
+		eventTail
+	}
+	if err := json.Unmarshal(b, &head); err != nil {
+		return err
+	}
+	d, err := decodeData(head.Type, b)
+	if err != nil {
+		return err
+	}
+	*e = Event{Type: head.Type, Timestamp: head.Timestamp, Data: d, RawLine: head.RawLine, Source: head.Source}
+	return nil
+}
+
+// MarshalJSON encodes t with a leading "schema_version" field.
+func (t TypedEvent) MarshalJSON() ([]byte, error) {
+	type plain TypedEvent
+	return json.Marshal(struct {
+		SchemaVersion int `json:"schema_version"`
+		plain
+	}{SchemaVersion, plain(t)})
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) document describing
+// the JSON form of Event at SchemaVersion. The "type" property lists the
+// built-in event types, and also accepts any name valid for Register, so
+// the document does not depend on the custom types registered at run time.
+// Events of custom types may have properties beyond those listed, the
+// fields of their own payloads.
+//
+// The payload types (e.g. PlayerJoinData), which make up "data" in the
+// typed form, are included under "$defs", along with "TypedEvent", the
+// schema of the typed form itself.
+func JSONSchema() []byte {
+	s := schemaBuilder{defs: make(map[string]any)}
+
+	typeSchema := map[string]any{
+		"description": "A built-in event type, or a custom type added with Register.",
+		"anyOf": []any{
+			map[string]any{"enum": allTypes},
+			map[string]any{"type": "string", "pattern": "^[a-z0-9_]+$"},
+		},
+	}
+	common := func() (map[string]any, map[string]any) {
+		obj := s.object(reflect.TypeFor[eventHead]())
+		props := obj["properties"].(map[string]any)
+		maps.Copy(props, s.object(reflect.TypeFor[eventTail]())["properties"].(map[string]any))
+		props["schema_version"] = map[string]any{"const": SchemaVersion}
+		props["type"] = typeSchema
+		return obj, props
+	}
+
+	// The flat form has the fields of every payload type next to the
+	// common fields. The typed form has one of them under "data", chosen
+	// by "type"; custom types may have any object there.
+	root, props := common()
+	typed, typedProps := common()
+	typedProps["data"] = map[string]any{"type": "object"}
+	variants := make([]any, 0, len(allData)+1)
+	for _, d := range allData {
+		typ := reflect.TypeOf(d)
+		def := s.object(typ)
+		maps.Copy(props, def["properties"].(map[string]any))
+		def["description"] = fmt.Sprintf("Payload of %s events, the \"data\" of the typed form.", d.EventType())
+		s.defs[typ.Name()] = def
+		variants = append(variants, map[string]any{"properties": map[string]any{
+			"type": map[string]any{"const": d.EventType()},
+			"data": map[string]any{"$ref": "#/$defs/" + typ.Name()},
+		}})
+	}
+	variants = append(variants, map[string]any{"properties": map[string]any{
+		"type": map[string]any{"not": map[string]any{"enum": allTypes}},
+	}})
+	typed["oneOf"] = variants
+
+	// Custom events carry the keys of their own payload at the top level,
+	// so only built-in types are limited to the known properties
+	delete(root, "additionalProperties")
+	root["if"] = map[string]any{"properties": map[string]any{"type": map[string]any{"enum": allTypes}}}
+	root["then"] = map[string]any{"propertyNames": map[string]any{"enum": slices.Sorted(maps.Keys(props))}}
+	typed["description"] = "The typed form of an event (TypedEvent), with the payload under \"data\"."
+	s.defs["TypedEvent"] = typed
+
+	doc := map[string]any{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"title":       "vrclog event",
+		"description": fmt.Sprintf("A VRChat log event as emitted by vrclog, schema version %d.", SchemaVersion),
+		"$defs":       s.defs,
+	}
+	for k, v := range root {
+		doc[k] = v
+	}
+
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		panic(err) // the document only holds maps, slices and strings
+	}
+	return append(b, '\n')
+}
+
+// schemaBuilder maps Go types to JSON Schema, collecting named structs
+// referenced by pointer in defs.
+type schemaBuilder struct {
+	defs map[string]any
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// schema returns the schema of values of type t.
+func (s *schemaBuilder) schema(t reflect.Type) map[string]any {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct:
+		name := t.Elem().Name()
+		if _, ok := s.defs[name]; !ok {
+			s.defs[name] = nil // guard against recursion
+			s.defs[name] = s.object(t.Elem())
+		}
+		return map[string]any{"$ref": "#/$defs/" + name}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": s.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": s.schema(t.Elem())}
+	case reflect.Struct:
+		return s.object(t)
+	}
+	panic(fmt.Sprintf("event: no JSON Schema mapping for %s", t))
+}
+
+// object returns the schema of struct type t. Fields without omitempty
+// are required; unknown properties are not allowed.
+func (s *schemaBuilder) object(t reflect.Type) map[string]any {
+	props := make(map[string]any, t.NumField())
+	required := []string{}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		props[name] = s.schema(f.Type)
+		if opts != "omitempty" {
+			required = append(required, name)
+		}
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
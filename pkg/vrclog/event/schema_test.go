@@ -0,0 +1,464 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+var updateSchema = flag.Bool("update-schema", false, "write the schema file of a new SchemaVersion")
+
+// TestJSONSchema_Versioned fails when the wire format changes without a
+// SchemaVersion bump. Each version's schema is kept in testdata/schema;
+// files of earlier versions are never rewritten.
+func TestJSONSchema_Versioned(t *testing.T) {
+	got := JSONSchema()
+	path := filepath.Join("testdata", "schema", fmt.Sprintf("v%d.json", SchemaVersion))
+
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && *updateSchema {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("reading %s: %v\nRun with -update-schema to create it", path, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("JSON wire format differs from %s.\n"+
+			"If the change is intended, bump SchemaVersion and run with -update-schema.\ngot:\n%s", path, got)
+	}
+}
+
+func TestJSONSchema_CustomTypes(t *testing.T) {
+	cleanupRegistry(t)
+	before := JSONSchema()
+	typ := MustRegister("custom_schema_test")
+
+	// The document does not depend on registrations
+	if got := JSONSchema(); !bytes.Equal(got, before) {
+		t.Errorf("JSONSchema changed after Register:\n%s", got)
+	}
+
+	var doc struct {
+		Properties struct {
+			Type struct {
+				AnyOf []struct {
+					Enum    []Type `json:"enum"`
+					Pattern string `json:"pattern"`
+				} `json:"anyOf"`
+			} `json:"type"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(before, &doc); err != nil {
+		t.Fatalf("JSONSchema is not valid JSON: %v", err)
+	}
+	anyOf := doc.Properties.Type.AnyOf
+	if len(anyOf) != 2 {
+		t.Fatalf("type anyOf = %+v, want built-in enum and name pattern", anyOf)
+	}
+	if !slices.Equal(anyOf[0].Enum, allTypes) {
+		t.Errorf("type enum = %v, want built-in types %v", anyOf[0].Enum, allTypes)
+	}
+	pattern := regexp.MustCompile(anyOf[1].Pattern)
+	if !pattern.MatchString(string(typ)) || pattern.MatchString("Custom-Type") {
+		t.Errorf("type pattern %q does not match exactly the valid Register names", anyOf[1].Pattern)
+	}
+}
+
+// TestJSONSchema_TypedEvent checks that typed events of every built-in
+// type, with every field set, match exactly one variant of the TypedEvent
+// schema, whose payload definition describes their "data".
+func TestJSONSchema_TypedEvent(t *testing.T) {
+	type variant struct {
+		Properties struct {
+			Type struct {
+				Const Type `json:"const"`
+				Not   struct {
+					Enum []Type `json:"enum"`
+				} `json:"not"`
+			} `json:"type"`
+			Data struct {
+				Ref string `json:"$ref"`
+			} `json:"data"`
+		} `json:"properties"`
+	}
+	type object struct {
+		Properties map[string]any `json:"properties"`
+		OneOf      []variant      `json:"oneOf"`
+	}
+	var doc struct {
+		Defs map[string]object `json:"$defs"`
+	}
+	if err := json.Unmarshal(JSONSchema(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	typed, ok := doc.Defs["TypedEvent"]
+	if !ok {
+		t.Fatal("no TypedEvent in $defs")
+	}
+
+	for _, d := range allData {
+		data := reflect.New(reflect.TypeOf(d)).Elem()
+		fill(data)
+		ev := Event{
+			Type:      d.EventType(),
+			Timestamp: time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC),
+			Data:      data.Interface().(Data),
+			RawLine:   "x",
+			Source:    &Source{File: "x", Line: 1, Offset: 1, Seq: 1},
+		}
+		b, err := json.Marshal(ev.Typed())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got map[string]json.RawMessage
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+		for key := range got {
+			if _, ok := typed.Properties[key]; !ok {
+				t.Errorf("%s: marshaled key %q is not in the TypedEvent schema", ev.Type, key)
+			}
+		}
+
+		var matches []variant
+		for _, v := range typed.OneOf {
+			if v.Properties.Type.Const == ev.Type ||
+				(v.Properties.Type.Const == "" && !slices.Contains(v.Properties.Type.Not.Enum, ev.Type)) {
+				matches = append(matches, v)
+			}
+		}
+		if len(matches) != 1 {
+			t.Errorf("%s: matches %d TypedEvent variants, want 1", ev.Type, len(matches))
+			continue
+		}
+		def, ok := doc.Defs[strings.TrimPrefix(matches[0].Properties.Data.Ref, "#/$defs/")]
+		if !ok {
+			t.Errorf("%s: data $ref %q not in $defs", ev.Type, matches[0].Properties.Data.Ref)
+			continue
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(got["data"], &fields); err != nil {
+			t.Fatal(err)
+		}
+		for key := range fields {
+			if _, ok := def.Properties[key]; !ok {
+				t.Errorf("%s: data key %q is not in %s", ev.Type, key, matches[0].Properties.Data.Ref)
+			}
+		}
+		for key := range def.Properties {
+			if _, ok := fields[key]; !ok {
+				t.Errorf("%s: %s property %q is not marshaled", ev.Type, matches[0].Properties.Data.Ref, key)
+			}
+		}
+	}
+
+	// Custom types match only the open variant
+	var custom int
+	for _, v := range typed.OneOf {
+		if v.Properties.Type.Const == "" && !slices.Contains(v.Properties.Type.Not.Enum, "custom_schema_test") {
+			custom++
+		}
+	}
+	if custom != 1 {
+		t.Errorf("custom type matches %d TypedEvent variants, want 1", custom)
+	}
+}
+
+// TestJSONSchema_MatchesMarshal checks that events of every built-in type,
+// with every field set, marshal to exactly the properties described by
+// the schema.
+func TestJSONSchema_MatchesMarshal(t *testing.T) {
+	got := make(map[string]any)
+	for _, d := range allData {
+		data := reflect.New(reflect.TypeOf(d)).Elem()
+		fill(data)
+		ev := Event{
+			Type:      d.EventType(),
+			Timestamp: time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC),
+			Data:      data.Interface().(Data),
+			RawLine:   "x",
+			Source:    &Source{File: "x", Line: 1, Offset: 1, Seq: 1},
+		}
+
+		b, err := json.Marshal(ev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var doc struct {
+		Properties map[string]any `json:"properties"`
+		Required   []string       `json:"required"`
+	}
+	if err := json.Unmarshal(JSONSchema(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	for key := range got {
+		if _, ok := doc.Properties[key]; !ok {
+			t.Errorf("marshaled key %q is not in the schema", key)
+		}
+	}
+	for key := range doc.Properties {
+		if _, ok := got[key]; !ok {
+			t.Errorf("schema property %q is not marshaled", key)
+		}
+	}
+
+	// Required properties are present even on a zero Event
+	data, err := json.Marshal(Event{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range doc.Required {
+		if !bytes.Contains(data, []byte(`"`+key+`":`)) {
+			t.Errorf("required property %q missing from %s", key, data)
+		}
+	}
+}
+
+// TestJSONSchema_Validate validates marshaled events of built-in and
+// custom types against the schema, in the flat and the typed form.
+func TestJSONSchema_Validate(t *testing.T) {
+	var doc map[string]any
+	if err := json.Unmarshal(JSONSchema(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	defs := doc["$defs"].(map[string]any)
+	typed := defs["TypedEvent"].(map[string]any)
+	ts := time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC)
+
+	var events []Event
+	for _, d := range allData {
+		data := reflect.New(reflect.TypeOf(d)).Elem()
+		fill(data)
+		events = append(events, Event{Type: d.EventType(), Timestamp: ts, Data: data.Interface().(Data), RawLine: "x", Source: &Source{File: "x"}})
+	}
+	events = append(events,
+		New(ts, customData{Count: 2}),
+		Event{Type: "custom_data_test", Timestamp: ts},
+	)
+	for _, ev := range events {
+		for _, form := range []struct {
+			name   string
+			v      any
+			schema map[string]any
+		}{
+			{"flat", ev, doc},
+			{"typed", ev.Typed(), typed},
+		} {
+			if err := validate(t, form.schema, defs, form.v); err != nil {
+				t.Errorf("%s %s event does not validate: %v", form.name, ev.Type, err)
+			}
+		}
+	}
+
+	invalid := []struct {
+		name string
+		json string
+	}{
+		{"unknown key on built-in type", `{"schema_version":1,"type":"player_join","timestamp":"2024-01-15T23:59:59Z","count":2}`},
+		{"payload key of another type", `{"schema_version":1,"type":"player_join","timestamp":"2024-01-15T23:59:59Z","player_name":"x","nope":1}`},
+		{"invalid type name", `{"schema_version":1,"type":"Custom-Type","timestamp":"2024-01-15T23:59:59Z"}`},
+		{"missing timestamp", `{"schema_version":1,"type":"custom_data_test"}`},
+	}
+	for _, tt := range invalid {
+		if err := validate(t, doc, defs, json.RawMessage(tt.json)); err == nil {
+			t.Errorf("%s: %s validates, want error", tt.name, tt.json)
+		}
+	}
+}
+
+// validate checks v, marshaled to JSON, against schema. It implements the
+// keywords used by JSONSchema, with "$ref" resolved in defs.
+func validate(t *testing.T, schema, defs map[string]any, v any) error {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var inst any
+	if err := json.Unmarshal(b, &inst); err != nil {
+		t.Fatal(err)
+	}
+	return validateValue(schema, defs, inst)
+}
+
+func validateValue(schema, defs map[string]any, v any) error {
+	sub := func(s any, v any) error { return validateValue(s.(map[string]any), defs, v) }
+
+	if ref, ok := schema["$ref"].(string); ok {
+		return sub(defs[strings.TrimPrefix(ref, "#/$defs/")], v)
+	}
+	if typ, ok := schema["type"].(string); ok {
+		var ok bool
+		switch typ {
+		case "string":
+			_, ok = v.(string)
+		case "boolean":
+			_, ok = v.(bool)
+		case "integer":
+			f, isNum := v.(float64)
+			ok = isNum && f == float64(int64(f))
+		case "array":
+			_, ok = v.([]any)
+		case "object":
+			_, ok = v.(map[string]any)
+		}
+		if !ok {
+			return fmt.Errorf("%v is not of type %s", v, typ)
+		}
+	}
+	if c, ok := schema["const"]; ok && !reflect.DeepEqual(c, v) {
+		return fmt.Errorf("%v is not %v", v, c)
+	}
+	if enum, ok := schema["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool { return reflect.DeepEqual(e, v) }) {
+		return fmt.Errorf("%v is not in %v", v, enum)
+	}
+	if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(v.(string)) {
+		return fmt.Errorf("%q does not match %s", v, pattern)
+	}
+	if min, ok := schema["minimum"].(float64); ok && v.(float64) < min {
+		return fmt.Errorf("%v is less than %v", v, min)
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok && !slices.ContainsFunc(anyOf, func(s any) bool { return sub(s, v) == nil }) {
+		return fmt.Errorf("%v matches none of anyOf", v)
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		n := 0
+		for _, s := range oneOf {
+			if sub(s, v) == nil {
+				n++
+			}
+		}
+		if n != 1 {
+			return fmt.Errorf("%v matches %d of oneOf, want 1", v, n)
+		}
+	}
+	if not, ok := schema["not"]; ok && sub(not, v) == nil {
+		return fmt.Errorf("%v matches not", v)
+	}
+	if cond, ok := schema["if"]; ok && sub(cond, v) == nil {
+		if err := sub(schema["then"], v); err != nil {
+			return err
+		}
+	}
+	if items, ok := schema["items"]; ok {
+		for _, item := range v.([]any) {
+			if err := sub(items, item); err != nil {
+				return err
+			}
+		}
+	}
+
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	required, _ := schema["required"].([]any)
+	for _, key := range required {
+		if _, ok := obj[key.(string)]; !ok {
+			return fmt.Errorf("missing required property %q", key)
+		}
+	}
+	props, _ := schema["properties"].(map[string]any)
+	for key, val := range obj {
+		if names, ok := schema["propertyNames"]; ok {
+			if err := sub(names, key); err != nil {
+				return fmt.Errorf("property name: %w", err)
+			}
+		}
+		if ps, ok := props[key]; ok {
+			if err := sub(ps, val); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			continue
+		}
+		switch extra := schema["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				return fmt.Errorf("unknown property %q", key)
+			}
+		case map[string]any:
+			if err := sub(extra, val); err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+func TestMarshalJSON_SchemaVersion(t *testing.T) {
+	ev := Event{Type: PlayerJoin, Timestamp: time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC), Data: PlayerJoinData{PlayerName: "TestUser"}}
+	prefix := fmt.Sprintf(`{"schema_version":%d,"type":"player_join",`, SchemaVersion)
+
+	for _, v := range []any{ev, &ev, ev.Typed()} {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(data), prefix) {
+			t.Errorf("Marshal(%T) = %s, want prefix %s", v, data, prefix)
+		}
+	}
+
+	// The version is accepted, and ignored, when decoding
+	var decoded Event
+	if err := json.Unmarshal([]byte(prefix+`"timestamp":"2024-01-15T23:59:59Z","player_name":"TestUser"}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, ev) {
+		t.Errorf("Unmarshal = %+v, want %+v", decoded, ev)
+	}
+}
+
+// fill sets every field of v to a non-zero value.
+func fill(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fill(v.Index(0))
+	case reflect.Map:
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(reflect.ValueOf("k"), reflect.ValueOf("v"))
+	case reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		fill(v.Elem())
+	case reflect.Struct:
+		if v.Type() == timeType {
+			v.Set(reflect.ValueOf(time.Date(2024, 1, 15, 23, 59, 59, 0, time.UTC)))
+			return
+		}
+		for i := range v.NumField() {
+			fill(v.Field(i))
+		}
+	}
+}
@@ -30,13 +30,13 @@ func ExampleWatchWithOptions() {
 			if !ok {
 				return
 			}
-			switch event.Type {
-			case vrclog.EventPlayerJoin:
-				fmt.Printf("%s joined\n", event.PlayerName)
-			case vrclog.EventPlayerLeft:
-				fmt.Printf("%s left\n", event.PlayerName)
-			case vrclog.EventWorldJoin:
-				fmt.Printf("Joined world: %s\n", event.WorldName)
+			switch d := event.Data.(type) {
+			case vrclog.PlayerJoinData:
+				fmt.Printf("%s joined\n", d.PlayerName)
+			case vrclog.PlayerLeftData:
+				fmt.Printf("%s left\n", d.PlayerName)
+			case vrclog.WorldJoinData:
+				fmt.Printf("Joined world: %s\n", d.WorldName)
 			}
 		case err, ok := <-errs:
 			if !ok {
@@ -78,10 +78,10 @@ func ExampleNewWatcherWithOptions() {
 			if !ok {
 				return
 			}
-			fmt.Printf("[%s] %s: %s\n",
+			fmt.Printf("[%s] %s: %+v\n",
 				event.Timestamp.Format("15:04:05"),
 				event.Type,
-				event.PlayerName)
+				event.Data)
 		case err, ok := <-errs:
 			if !ok {
 				return
@@ -110,7 +110,7 @@ func ExampleParseLine() {
 	}
 
 	fmt.Printf("Type: %s\n", event.Type)
-	fmt.Printf("Player: %s\n", event.PlayerName)
+	fmt.Printf("Player: %s\n", event.Data.(vrclog.PlayerJoinData).PlayerName)
 	// Output:
 	// Type: player_join
 	// Player: TestUser
@@ -128,13 +128,51 @@ func ExampleParseLine_worldJoin() {
 
 	if event != nil {
 		fmt.Printf("Type: %s\n", event.Type)
-		fmt.Printf("World: %s\n", event.WorldName)
+		fmt.Printf("World: %s\n", event.Data.(vrclog.WorldJoinData).WorldName)
 	}
 	// Output:
 	// Type: world_join
 	// World: Test World
 }
 
+// ExampleData demonstrates handling events by their typed payload.
+func ExampleData() {
+	line := "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser (usr_abc)"
+
+	event, err := vrclog.ParseLine(line)
+	if err != nil || event == nil {
+		return
+	}
+
+	switch d := event.Data.(type) {
+	case vrclog.PlayerJoinData:
+		fmt.Printf("%s joined (%s)\n", d.PlayerName, d.PlayerID)
+	case vrclog.WorldJoinData:
+		fmt.Printf("Entered %s\n", d.WorldName)
+	}
+	// Output:
+	// TestUser joined (usr_abc)
+}
+
+// ExampleParseInstanceID demonstrates decomposing an instance ID.
+func ExampleParseInstanceID() {
+	info, err := vrclog.ParseInstanceID("12345~private(usr_abc)~canRequestInvite~region(jp)~nonce(xyz)")
+	if err != nil {
+		log.Printf("invalid instance: %v", err)
+		return
+	}
+
+	fmt.Printf("Name: %s\n", info.Name)
+	fmt.Printf("Access: %s\n", info.AccessType)
+	fmt.Printf("Owner: %s\n", info.OwnerID)
+	fmt.Printf("Region: %s\n", info.Region)
+	// Output:
+	// Name: 12345
+	// Access: invite+
+	// Owner: usr_abc
+	// Region: jp
+}
+
 // Example_errorsIs demonstrates how to check for sentinel errors using errors.Is.
 // This is useful for checking specific error conditions regardless of wrapping.
 func Example_errorsIs() {
@@ -1,5 +1,7 @@
 package vrclog
 
+import "strings"
+
 // compiledFilter holds pre-compiled filter configuration for efficient event filtering.
 // It is created from FilterConfig during watcher/parser initialization.
 type compiledFilter struct {
@@ -57,3 +59,56 @@ func (f *compiledFilter) Allows(t EventType) bool {
 
 	return true
 }
+
+// entryFilter holds level and category filters for LogEntry streams.
+// A nil *entryFilter allows all entries.
+type entryFilter struct {
+	levels     map[LogLevel]struct{}
+	categories map[string]struct{} // lowercase category names
+}
+
+// setLevels replaces the level filter. An empty list allows all levels.
+func (f *entryFilter) setLevels(levels []LogLevel) {
+	f.levels = nil
+	if len(levels) == 0 {
+		return
+	}
+	f.levels = make(map[LogLevel]struct{}, len(levels))
+	for _, l := range levels {
+		f.levels[l] = struct{}{}
+	}
+}
+
+// setCategories replaces the category filter. An empty list allows all
+// categories. Category names are matched case-insensitively.
+func (f *entryFilter) setCategories(categories []string) {
+	f.categories = nil
+	if len(categories) == 0 {
+		return
+	}
+	f.categories = make(map[string]struct{}, len(categories))
+	for _, c := range categories {
+		f.categories[strings.ToLower(strings.TrimSpace(c))] = struct{}{}
+	}
+}
+
+// Allows returns true if the entry's level and category pass the filter.
+func (f *entryFilter) Allows(e *LogEntry) bool {
+	if f == nil {
+		return true
+	}
+
+	if len(f.levels) > 0 {
+		if _, ok := f.levels[e.Level]; !ok {
+			return false
+		}
+	}
+
+	if len(f.categories) > 0 {
+		if _, ok := f.categories[strings.ToLower(e.Category)]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -89,3 +89,66 @@ func TestNewCompiledFilter_NilForEmpty(t *testing.T) {
 		t.Error("newCompiledFilter([], []) should return nil")
 	}
 }
+
+func TestEntryFilter_Allows(t *testing.T) {
+	tests := []struct {
+		name       string
+		levels     []LogLevel
+		categories []string
+		entry      LogEntry
+		want       bool
+	}{
+		{
+			name:  "empty filter allows all",
+			entry: LogEntry{Level: LevelLog, Category: "Behaviour"},
+			want:  true,
+		},
+		{
+			name:   "level match",
+			levels: []LogLevel{LevelWarning, LevelError},
+			entry:  LogEntry{Level: LevelError},
+			want:   true,
+		},
+		{
+			name:   "level mismatch",
+			levels: []LogLevel{LevelWarning, LevelError},
+			entry:  LogEntry{Level: LevelLog},
+			want:   false,
+		},
+		{
+			name:       "category match is case-insensitive",
+			categories: []string{"behaviour"},
+			entry:      LogEntry{Level: LevelLog, Category: "Behaviour"},
+			want:       true,
+		},
+		{
+			name:       "category mismatch",
+			categories: []string{"Network"},
+			entry:      LogEntry{Level: LevelLog, Category: "Behaviour"},
+			want:       false,
+		},
+		{
+			name:       "both must match",
+			levels:     []LogLevel{LevelWarning},
+			categories: []string{"Avatar"},
+			entry:      LogEntry{Level: LevelLog, Category: "Avatar"},
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &entryFilter{}
+			f.setLevels(tt.levels)
+			f.setCategories(tt.categories)
+			if got := f.Allows(&tt.entry); got != tt.want {
+				t.Errorf("Allows(%+v) = %v, want %v", tt.entry, got, tt.want)
+			}
+		})
+	}
+
+	var nilFilter *entryFilter
+	if !nilFilter.Allows(&LogEntry{}) {
+		t.Error("nil entryFilter should allow all entries")
+	}
+}
@@ -18,6 +18,13 @@ type watchConfig struct {
 	maxReplayLines int
 	logger         *slog.Logger
 	filter         *compiledFilter
+	parsers        parserChain
+	flushTimeout   time.Duration
+	entryHandler   func(LogEntry)
+	entryFilter    *entryFilter
+	location       *time.Location
+	unrecognized   bool
+	source         bool
 }
 
 // defaultWatchConfig returns a watchConfig with sensible defaults.
@@ -25,6 +32,7 @@ func defaultWatchConfig() *watchConfig {
 	return &watchConfig{
 		pollInterval:   2 * time.Second,
 		maxReplayLines: DefaultMaxReplayLastN,
+		flushTimeout:   DefaultFlushTimeout,
 	}
 }
 
@@ -67,6 +75,11 @@ func (c *watchConfig) validate() error {
 		return fmt.Errorf("poll interval must be non-negative, got %v", c.pollInterval)
 	}
 
+	// Validate FlushTimeout
+	if c.flushTimeout < 0 {
+		return fmt.Errorf("flush timeout must be non-negative, got %v", c.flushTimeout)
+	}
+
 	return nil
 }
 
@@ -87,6 +100,54 @@ func WithPollInterval(interval time.Duration) WatchOption {
 	}
 }
 
+// WithFlushTimeout sets how long the watcher waits for continuation lines
+// (e.g. stack traces) before emitting a buffered multi-line entry.
+// Every event is delayed by up to this long, since the entry it comes
+// from may not be complete yet. Lower values reduce event latency; higher
+// values tolerate slow writers.
+//
+// Zero disables the wait: entries are emitted as soon as their first line
+// is read, and continuation lines written after it are dropped (RawLine
+// and udon_exception stack traces only hold the first line), and app_start
+// is released line by line. The two halves of a world_join are merged
+// regardless of the timeout.
+// Default: 250 milliseconds.
+func WithFlushTimeout(timeout time.Duration) WatchOption {
+	return func(c *watchConfig) {
+		c.flushTimeout = timeout
+	}
+}
+
+// WithLocation sets the time zone that log timestamps are interpreted in.
+// VRChat writes timestamps in the local time of the machine that produced
+// the log, without a UTC offset; set this when reading logs from a machine
+// in another time zone. Default: time.Local.
+func WithLocation(loc *time.Location) WatchOption {
+	return func(c *watchConfig) {
+		c.location = loc
+	}
+}
+
+// WithUnrecognized emits an EventUnrecognized event for each [Behaviour]
+// line that no parser recognized, to notice log format changes.
+// Default: false.
+func WithUnrecognized(emit bool) WatchOption {
+	return func(c *watchConfig) {
+		c.unrecognized = emit
+	}
+}
+
+// WithSource sets Event.Source on every event to the file, line and byte
+// offset it was read from, with sequence numbers counting events since
+// Watch was called. Line numbers are only known when a file is read from
+// its start (ReplayFromStart, ReplaySinceTime, or a new file after log
+// rotation); otherwise Source.Line is 0. Default: false.
+func WithSource(include bool) WatchOption {
+	return func(c *watchConfig) {
+		c.source = include
+	}
+}
+
 // WithIncludeRawLine includes the original log line in Event.RawLine.
 // Default: false.
 func WithIncludeRawLine(include bool) WatchOption {
@@ -177,6 +238,59 @@ func WithFilter(include, exclude []EventType) WatchOption {
 	}
 }
 
+// WithParsers registers custom parsers that are tried, in order, for lines
+// the built-in parser does not recognize.
+// Multiple calls append to the list of parsers.
+func WithParsers(parsers ...Parser) WatchOption {
+	return func(c *watchConfig) {
+		c.parsers = appendParsers(c.parsers, parsers)
+	}
+}
+
+// WithEntryHandler streams every log entry (not just recognized events) to fn.
+// fn is called synchronously from the watcher goroutine for each entry that
+// passes WithEntryLevels/WithEntryCategories, before the entry is parsed into
+// an event, so it should return quickly.
+// Default: nil (entries are not streamed).
+func WithEntryHandler(fn func(LogEntry)) WatchOption {
+	return func(c *watchConfig) {
+		c.entryHandler = fn
+	}
+}
+
+// WithEntryLevels limits entries passed to the WithEntryHandler callback to
+// the specified levels. Events are not affected.
+func WithEntryLevels(levels ...LogLevel) WatchOption {
+	return func(c *watchConfig) {
+		if c.entryFilter == nil {
+			c.entryFilter = &entryFilter{}
+		}
+		c.entryFilter.setLevels(levels)
+	}
+}
+
+// WithEntryCategories limits entries passed to the WithEntryHandler callback
+// to the specified categories (e.g. "Behaviour", "Network"), matched
+// case-insensitively. Events are not affected.
+func WithEntryCategories(categories ...string) WatchOption {
+	return func(c *watchConfig) {
+		if c.entryFilter == nil {
+			c.entryFilter = &entryFilter{}
+		}
+		c.entryFilter.setCategories(categories)
+	}
+}
+
+// appendParsers appends non-nil parsers to chain.
+func appendParsers(chain parserChain, parsers []Parser) parserChain {
+	for _, p := range parsers {
+		if p != nil {
+			chain = append(chain, p)
+		}
+	}
+	return chain
+}
+
 // ParseOption configures ParseFile/ParseDir behavior.
 type ParseOption func(*parseConfig)
 
@@ -187,6 +301,12 @@ type parseConfig struct {
 	since          time.Time
 	until          time.Time
 	stopOnError    bool
+	parsers        parserChain
+	entryFilter    *entryFilter
+	location       *time.Location
+	unrecognized   bool
+	drift          *DriftReport
+	source         bool
 }
 
 // defaultParseConfig returns a parseConfig with sensible defaults.
@@ -245,6 +365,14 @@ func WithParseIncludeRawLine(include bool) ParseOption {
 	}
 }
 
+// WithParseSource sets Event.Source on every event to the file, line and
+// byte offset it was read from, with a sequence number. Default: false.
+func WithParseSource(include bool) ParseOption {
+	return func(c *parseConfig) {
+		c.source = include
+	}
+}
+
 // WithParseTimeRange filters events to only include those within the time range.
 // since is inclusive, until is exclusive.
 // Zero values are ignored (no filtering for that boundary).
@@ -269,6 +397,31 @@ func WithParseUntil(until time.Time) ParseOption {
 	}
 }
 
+// WithParseLocation sets the time zone that log timestamps are interpreted
+// in. Default: time.Local.
+func WithParseLocation(loc *time.Location) ParseOption {
+	return func(c *parseConfig) {
+		c.location = loc
+	}
+}
+
+// WithParseUnrecognized emits an EventUnrecognized event for each
+// [Behaviour] line that no parser recognized. Default: false.
+func WithParseUnrecognized(emit bool) ParseOption {
+	return func(c *parseConfig) {
+		c.unrecognized = emit
+	}
+}
+
+// WithParseDriftReport collects counts of unrecognized [Behaviour] line
+// shapes into report while parsing, whether or not unrecognized events
+// are emitted. Read report after iteration ends.
+func WithParseDriftReport(report *DriftReport) ParseOption {
+	return func(c *parseConfig) {
+		c.drift = report
+	}
+}
+
 // WithParseStopOnError stops parsing on the first error instead of skipping.
 // Default: false (skip malformed lines and continue).
 func WithParseStopOnError(stop bool) ParseOption {
@@ -276,3 +429,34 @@ func WithParseStopOnError(stop bool) ParseOption {
 		c.stopOnError = stop
 	}
 }
+
+// WithParseParsers registers custom parsers that are tried, in order, for lines
+// the built-in parser does not recognize.
+// Multiple calls append to the list of parsers.
+func WithParseParsers(parsers ...Parser) ParseOption {
+	return func(c *parseConfig) {
+		c.parsers = appendParsers(c.parsers, parsers)
+	}
+}
+
+// WithParseEntryLevels limits entries yielded by ParseFileEntries to the
+// specified levels.
+func WithParseEntryLevels(levels ...LogLevel) ParseOption {
+	return func(c *parseConfig) {
+		if c.entryFilter == nil {
+			c.entryFilter = &entryFilter{}
+		}
+		c.entryFilter.setLevels(levels)
+	}
+}
+
+// WithParseEntryCategories limits entries yielded by ParseFileEntries to the
+// specified categories, matched case-insensitively.
+func WithParseEntryCategories(categories ...string) ParseOption {
+	return func(c *parseConfig) {
+		if c.entryFilter == nil {
+			c.entryFilter = &entryFilter{}
+		}
+		c.entryFilter.setCategories(categories)
+	}
+}
@@ -219,8 +219,8 @@ func TestWatchWithOptions_ReplayOptions(t *testing.T) {
 	for i, want := range expected {
 		select {
 		case event := <-events:
-			if event.PlayerName != want {
-				t.Errorf("event %d: got player %q, want %q", i, event.PlayerName, want)
+			if data[vrclog.PlayerJoinData](event).PlayerName != want {
+				t.Errorf("event %d: got player %q, want %q", i, data[vrclog.PlayerJoinData](event).PlayerName, want)
 			}
 		case err := <-errs:
 			t.Fatalf("unexpected error: %v", err)
@@ -252,8 +252,8 @@ func TestWatchWithOptions_ReplayFromStart(t *testing.T) {
 
 	select {
 	case event := <-events:
-		if event.PlayerName != "ExistingUser" {
-			t.Errorf("got player %q, want ExistingUser", event.PlayerName)
+		if data[vrclog.PlayerJoinData](event).PlayerName != "ExistingUser" {
+			t.Errorf("got player %q, want ExistingUser", data[vrclog.PlayerJoinData](event).PlayerName)
 		}
 	case err := <-errs:
 		t.Fatalf("unexpected error: %v", err)
@@ -28,7 +28,9 @@ import (
 //	if err != nil {
 //	    log.Printf("parse error: %v", err)
 //	} else if event != nil {
-//	    fmt.Printf("Player joined: %s\n", event.PlayerName)
+//	    if d, ok := event.Data.(vrclog.PlayerJoinData); ok {
+//	        fmt.Printf("Player joined: %s\n", d.PlayerName)
+//	    }
 //	}
 //	// event == nil && err == nil means line is not a recognized event
 func ParseLine(line string) (*Event, error) {
@@ -39,6 +41,14 @@ func ParseLine(line string) (*Event, error) {
 // The file is opened lazily on first iteration, so the returned iterator
 // is cheap to create but must be consumed to release resources.
 //
+// Untimestamped continuation lines (e.g. stack traces) are grouped with the
+// preceding timestamped line, so RawLine carries the full multi-line entry.
+//
+// Events are yielded in log order, except that events merging several
+// lines are yielded once complete: a world_join comes after any events
+// logged between its "Joining" and "Entering Room" lines, with the
+// timestamp of the first.
+//
 // The iterator yields (Event, error) pairs. When an error occurs:
 //   - File open errors: yields (Event{}, error) once and stops
 //   - Parse errors: skips the line by default, or stops if WithParseStopOnError is set
@@ -62,70 +72,165 @@ func ParseFile(ctx context.Context, path string, opts ...ParseOption) iter.Seq2[
 	}
 
 	cfg := applyParseOptions(opts)
+	if cfg.source {
+		return numbered(parseFile(ctx, path, cfg))
+	}
+	return parseFile(ctx, path, cfg)
+}
 
+// parseFile is ParseFile with an already-resolved configuration.
+// Events have no sequence numbers; see numbered.
+func parseFile(ctx context.Context, path string, cfg *parseConfig) iter.Seq2[Event, error] {
 	return func(yield func(Event, error) bool) {
-		// Lazy file open
-		file, err := os.Open(path)
-		if err != nil {
-			yield(Event{}, err)
-			return
+		var sess session
+		stopped := false // consumer break or error: no more yields
+
+		// emit filters ev and yields it. It returns false once iteration
+		// must stop (consumer break or past the time window).
+		emit := func(ev *Event) bool {
+			// Apply event type filter
+			if cfg.filter != nil && !cfg.filter.Allows(EventType(ev.Type)) {
+				return true
+			}
+
+			// Apply time range filter
+			if !cfg.since.IsZero() && ev.Timestamp.Before(cfg.since) {
+				return true
+			}
+			if !cfg.until.IsZero() && ev.Timestamp.After(cfg.until) {
+				return false // Past the time window, stop reading
+			}
+
+			// The session always records the raw line; drop it unless requested
+			if !cfg.includeRawLine {
+				ev.RawLine = ""
+			}
+
+			if !yield(*ev, nil) {
+				stopped = true // Consumer requested stop (break)
+				return false
+			}
+			return true
 		}
-		defer file.Close()
 
-		scanner := bufio.NewScanner(file)
-		// Increase buffer size for long lines
-		buf := make([]byte, 0, 64*1024)
-		scanner.Buffer(buf, 512*1024)
+		err := readEntries(ctx, path, func(raw []byte, pos linePos) bool {
+			if cfg.drift != nil {
+				cfg.drift.Entries++
+			}
 
-		for scanner.Scan() {
-			// Context cancellation check
-			if err := ctx.Err(); err != nil {
-				yield(Event{}, err)
-				return
+			// Fast path: skip entries that can neither be built-in events
+			// nor update the session, without converting them to strings
+			if len(cfg.parsers) == 0 && !parser.MayMatch(raw) &&
+				!(cfg.detectsDrift() && parser.HasDriftTag(raw)) {
+				return true
 			}
 
-			line := scanner.Text()
-			ev, err := parser.Parse(line)
+			entry := string(raw)
+			ev, err := cfg.parsers.parse(entry, cfg.location)
 			if err != nil {
 				if cfg.stopOnError {
-					yield(Event{}, &ParseError{Line: line, Err: err})
-					return
+					stopped = true
+					yield(Event{}, &ParseError{Line: entry, Err: err})
+					return false
 				}
 				// Skip malformed lines by default
-				continue
+				return true
 			}
-			if ev == nil {
-				continue // Not a recognized event
+			if ev == nil && cfg.detectsDrift() {
+				ev = cfg.checkDrift(entry)
 			}
-
-			// Apply event type filter
-			if cfg.filter != nil && !cfg.filter.Allows(EventType(ev.Type)) {
-				continue
+			if ev != nil && cfg.source {
+				ev.Source = pos.source(path)
 			}
 
-			// Apply time range filter
-			if !cfg.since.IsZero() && ev.Timestamp.Before(cfg.since) {
-				continue
+			// Track session state before filtering so skipped entries still count
+			for _, ev := range sess.observe(entry, ev) {
+				if !emit(ev) {
+					return false
+				}
 			}
-			if !cfg.until.IsZero() && ev.Timestamp.After(cfg.until) {
-				return // Past the time window, stop iteration
+			return true
+		})
+		if err != nil {
+			yield(Event{}, err)
+			return
+		}
+		if stopped {
+			return
+		}
+		// Held events are older than the entry that released them, so they
+		// may still be in the time window after an event past it
+		for _, ev := range sess.flush(true) {
+			if !emit(ev) && stopped {
+				return
 			}
+		}
+	}
+}
 
-			// Include raw line if requested
-			if cfg.includeRawLine {
-				ev.RawLine = line
-			}
+// readEntries opens path and calls fn for each log entry, grouping
+// continuation lines with their header (see parser.Assembler), until fn
+// returns false. pos is the position of the entry's header line.
+// The file is opened lazily when readEntries is called.
+//
+// The entry passed to fn is only valid until fn returns; the read buffers
+// are reused for the next entry.
+//
+// Returns file open, read and context cancellation errors.
+func readEntries(ctx context.Context, path string, fn func(entry []byte, pos linePos) bool) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Increase buffer size for long lines
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 512*1024)
+
+	// Track line positions; ScanLines drops the line endings
+	var cur linePos
+	var next int64
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		if token != nil {
+			cur = linePos{line: cur.line + 1, offset: next}
+			next += int64(advance)
+		}
+		return advance, token, err
+	})
 
-			if !yield(*ev, nil) {
-				return // Consumer requested stop (break)
-			}
+	var asm parser.Assembler
+	var start linePos // position of the pending entry
+	for scanner.Scan() {
+		// Context cancellation check
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 
-		// Check for scanner errors
-		if err := scanner.Err(); err != nil {
-			yield(Event{}, err)
+		line := scanner.Bytes()
+		header := parser.IsEntryStart(line)
+		if entry, ok := asm.AddBytes(line); ok {
+			if !fn(entry, start) {
+				return nil
+			}
+		}
+		if header {
+			start = cur
 		}
 	}
+
+	// Check for scanner errors
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	// Emit the last entry of the file
+	if entry, ok := asm.FlushBytes(); ok {
+		fn(entry, start)
+	}
+	return nil
 }
 
 // ParseFileAll is a convenience function that parses a log file and collects
@@ -143,7 +248,7 @@ func ParseFile(ctx context.Context, path string, opts ...ParseOption) iter.Seq2[
 //	    log.Printf("error: %v", err)
 //	}
 //	for _, ev := range events {
-//	    fmt.Printf("player joined: %s\n", ev.PlayerName)
+//	    fmt.Printf("player joined: %s\n", ev.Data.(vrclog.PlayerJoinData).PlayerName)
 //	}
 func ParseFileAll(ctx context.Context, path string, opts ...ParseOption) ([]Event, error) {
 	seq := ParseFile(ctx, path, opts...)
@@ -250,8 +355,84 @@ func WithDirStopOnError(stop bool) ParseDirOption {
 	}
 }
 
-// ParseDir parses all VRChat log files in a directory, yielding events
-// in chronological order (by file modification time, oldest first).
+// WithDirParsers registers custom parsers that are tried, in order, for lines
+// the built-in parser does not recognize.
+// Multiple calls append to the list of parsers.
+func WithDirParsers(parsers ...Parser) ParseDirOption {
+	return func(c *parseDirConfig) {
+		c.parsers = appendParsers(c.parsers, parsers)
+	}
+}
+
+// WithDirLocation sets the time zone that log timestamps are interpreted
+// in. Default: time.Local.
+func WithDirLocation(loc *time.Location) ParseDirOption {
+	return func(c *parseDirConfig) {
+		c.location = loc
+	}
+}
+
+// WithDirUnrecognized emits an EventUnrecognized event for each
+// [Behaviour] line that no parser recognized.
+func WithDirUnrecognized(emit bool) ParseDirOption {
+	return func(c *parseDirConfig) {
+		c.unrecognized = emit
+	}
+}
+
+// WithDirDriftReport collects counts of unrecognized [Behaviour] line
+// shapes across all files into report while parsing, whether or not
+// unrecognized events are emitted. Read report after iteration ends.
+//
+// Example:
+//
+//	var report vrclog.DriftReport
+//	for ev, err := range vrclog.ParseDir(ctx, vrclog.WithDirDriftReport(&report)) {
+//	    // ...
+//	}
+//	for _, s := range report.Top(10) {
+//	    fmt.Printf("%6d  %s\n", s.Count, s.Shape)
+//	}
+func WithDirDriftReport(report *DriftReport) ParseDirOption {
+	return func(c *parseDirConfig) {
+		c.drift = report
+	}
+}
+
+// WithDirSource sets Event.Source on every event to the file, line and
+// byte offset it was read from, with sequence numbers counting events
+// across all files.
+func WithDirSource(include bool) ParseDirOption {
+	return func(c *parseDirConfig) {
+		c.source = include
+	}
+}
+
+// WithDirEntryLevels limits entries yielded by ParseDirEntries to the
+// specified levels.
+func WithDirEntryLevels(levels ...LogLevel) ParseDirOption {
+	return func(c *parseDirConfig) {
+		if c.entryFilter == nil {
+			c.entryFilter = &entryFilter{}
+		}
+		c.entryFilter.setLevels(levels)
+	}
+}
+
+// WithDirEntryCategories limits entries yielded by ParseDirEntries to the
+// specified categories, matched case-insensitively.
+func WithDirEntryCategories(categories ...string) ParseDirOption {
+	return func(c *parseDirConfig) {
+		if c.entryFilter == nil {
+			c.entryFilter = &entryFilter{}
+		}
+		c.entryFilter.setCategories(categories)
+	}
+}
+
+// ParseDir parses all VRChat log files in a directory, file by file in
+// chronological order (by file modification time, oldest first). Events
+// of each file are ordered as by ParseFile.
 //
 // The iterator yields (Event, error) pairs. When an error occurs:
 //   - Directory access errors: yields (Event{}, error) once and stops
@@ -267,82 +448,56 @@ func WithDirStopOnError(stop bool) ParseDirOption {
 //	        log.Printf("error: %v", err)
 //	        break
 //	    }
-//	    fmt.Printf("world: %s\n", ev.WorldName)
+//	    if d, ok := ev.Data.(vrclog.WorldJoinData); ok {
+//	        fmt.Printf("world: %s\n", d.WorldName)
+//	    }
 //	}
 func ParseDir(ctx context.Context, opts ...ParseDirOption) iter.Seq2[Event, error] {
 	cfg := applyParseDirOptions(opts)
+	events := parseDir(ctx, cfg, func(path string) iter.Seq2[Event, error] {
+		return parseFile(ctx, path, &cfg.parseConfig)
+	})
+	if cfg.source {
+		// Number events across all files
+		return numbered(events)
+	}
+	return events
+}
 
-	return func(yield func(Event, error) bool) {
-		var files []string
-		var err error
-
-		if len(cfg.paths) > 0 {
-			// Use explicit paths
-			files = cfg.paths
-		} else {
-			// Find log directory and list files
-			logDir := cfg.logDir
-			if logDir == "" {
-				logDir, err = logfinder.FindLogDir("")
-				if err != nil {
-					yield(Event{}, err)
-					return
-				}
-			}
+// parseDir iterates over the files selected by cfg in chronological order,
+// yielding the items produced by parseOne for each file.
+func parseDir[T any](ctx context.Context, cfg *parseDirConfig, parseOne func(path string) iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
 
-			// List all log files
-			files, err = listLogFiles(logDir)
-			if err != nil {
-				yield(Event{}, err)
-				return
-			}
+		files, err := cfg.resolveFiles()
+		if err != nil {
+			yield(zero, err)
+			return
 		}
 
 		if len(files) == 0 {
-			yield(Event{}, ErrNoLogFiles)
+			yield(zero, ErrNoLogFiles)
 			return
 		}
 
-		// Build ParseOptions from config
-		var parseOpts []ParseOption
-		if cfg.filter != nil {
-			include := make([]EventType, 0, len(cfg.filter.include))
-			for t := range cfg.filter.include {
-				include = append(include, t)
-			}
-			exclude := make([]EventType, 0, len(cfg.filter.exclude))
-			for t := range cfg.filter.exclude {
-				exclude = append(exclude, t)
-			}
-			parseOpts = append(parseOpts, WithParseFilter(include, exclude))
-		}
-		if !cfg.since.IsZero() || !cfg.until.IsZero() {
-			parseOpts = append(parseOpts, WithParseTimeRange(cfg.since, cfg.until))
-		}
-		if cfg.includeRawLine {
-			parseOpts = append(parseOpts, WithParseIncludeRawLine(true))
-		}
-		if cfg.stopOnError {
-			parseOpts = append(parseOpts, WithParseStopOnError(true))
-		}
-
 		// Parse each file
 		for _, file := range files {
 			if ctx.Err() != nil {
-				yield(Event{}, ctx.Err())
+				yield(zero, ctx.Err())
 				return
 			}
 
-			for ev, err := range ParseFile(ctx, file, parseOpts...) {
+			for item, err := range parseOne(file) {
 				if err != nil {
 					if cfg.stopOnError {
-						yield(Event{}, err)
+						yield(zero, err)
 						return
 					}
 					// Skip to next file on error
 					break
 				}
-				if !yield(ev, nil) {
+				if !yield(item, nil) {
 					return // Consumer requested stop
 				}
 			}
@@ -350,6 +505,26 @@ func ParseDir(ctx context.Context, opts ...ParseDirOption) iter.Seq2[Event, erro
 	}
 }
 
+// resolveFiles returns the files to parse: the explicit paths if set,
+// otherwise all log files in the (auto-detected) log directory.
+func (c *parseDirConfig) resolveFiles() ([]string, error) {
+	if len(c.paths) > 0 {
+		return c.paths, nil
+	}
+
+	// Find log directory and list files
+	logDir := c.logDir
+	if logDir == "" {
+		var err error
+		logDir, err = logfinder.FindLogDir("")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return listLogFiles(logDir)
+}
+
 // listLogFiles returns all VRChat log files in the directory,
 // sorted by modification time (oldest first).
 func listLogFiles(dir string) ([]string, error) {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -38,20 +40,20 @@ func TestParseFile_Basic(t *testing.T) {
 
 	// Verify event order
 	expected := []struct {
-		playerName string
-		eventType  vrclog.EventType
+		data      vrclog.Data
+		eventType vrclog.EventType
 	}{
-		{"User1", vrclog.EventPlayerJoin},
-		{"User2", vrclog.EventPlayerJoin},
-		{"User1", vrclog.EventPlayerLeft},
+		{vrclog.PlayerJoinData{PlayerName: "User1"}, vrclog.EventPlayerJoin},
+		{vrclog.PlayerJoinData{PlayerName: "User2"}, vrclog.EventPlayerJoin},
+		{vrclog.PlayerLeftData{PlayerName: "User1"}, vrclog.EventPlayerLeft},
 	}
 
 	for i, want := range expected {
 		if i >= len(events) {
 			break
 		}
-		if events[i].PlayerName != want.playerName {
-			t.Errorf("event %d: got player %q, want %q", i, events[i].PlayerName, want.playerName)
+		if events[i].Data != want.data {
+			t.Errorf("event %d: got %+v, want %+v", i, events[i].Data, want.data)
 		}
 		if events[i].Type != want.eventType {
 			t.Errorf("event %d: got type %v, want %v", i, events[i].Type, want.eventType)
@@ -192,8 +194,47 @@ func TestParseFile_WithTimeRange(t *testing.T) {
 		t.Errorf("got %d events, want 1", len(events))
 	}
 
-	if len(events) > 0 && events[0].PlayerName != "MiddleUser" {
-		t.Errorf("got player %q, want MiddleUser", events[0].PlayerName)
+	if len(events) > 0 && data[vrclog.PlayerJoinData](events[0]).PlayerName != "MiddleUser" {
+		t.Errorf("got player %q, want MiddleUser", data[vrclog.PlayerJoinData](events[0]).PlayerName)
+	}
+}
+
+func TestParseFile_TimeRangeHeldEvents(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := `2024.01.15 12:00:00 Log        -  [Behaviour] Joining wrld_12345678-1234-1234-1234-123456789abc:12345~region(jp)
+2024.01.15 12:00:50 Log        -  [VRC Camera] Took screenshot to: C:\shots\late.png
+2024.01.15 12:01:00 Log        -  [Behaviour] Entering Room: Test World
+`
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	until := time.Date(2024, 1, 15, 12, 0, 30, 0, time.Local)
+
+	// The held half is in the window even though the event releasing it is not
+	events, err := vrclog.ParseFileAll(context.Background(), logFile,
+		vrclog.WithParseTimeRange(time.Time{}, until),
+	)
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != vrclog.EventWorldJoin {
+		t.Fatalf("got %+v, want one world_join", events)
+	}
+	if d := data[vrclog.WorldJoinData](events[0]); d.WorldID != "wrld_12345678-1234-1234-1234-123456789abc" || d.WorldName != "" {
+		t.Errorf("world_join = %+v, want the ID half", d)
+	}
+
+	// Held events past the window are not emitted
+	events, err = vrclog.ParseFileAll(context.Background(), logFile,
+		vrclog.WithParseTimeRange(time.Time{}, until.Add(-time.Minute)),
+	)
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("got %+v, want no events", events)
 	}
 }
 
@@ -227,6 +268,366 @@ func TestParseFile_WithIncludeRawLine(t *testing.T) {
 	}
 }
 
+func TestParseFile_MultiLineEntry(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := "2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined User1\r\n" +
+		"\r\n" +
+		"2024.01.15 12:00:01 Error      -  [Behaviour] OnPlayerJoined User2\r\n" +
+		"  at Foo.Bar ()\r\n" +
+		"  at Foo.Baz ()\r\n" +
+		"\r\n" +
+		"2024.01.15 12:00:02 Log        -  [Behaviour] OnPlayerLeft User1\r\n" +
+		"trailing continuation\r\n"
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := vrclog.ParseFileAll(context.Background(), logFile,
+		vrclog.WithParseIncludeRawLine(true),
+	)
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+
+	want := []string{
+		"2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined User1",
+		"2024.01.15 12:00:01 Error      -  [Behaviour] OnPlayerJoined User2\n  at Foo.Bar ()\n  at Foo.Baz ()",
+		"2024.01.15 12:00:02 Log        -  [Behaviour] OnPlayerLeft User1\ntrailing continuation",
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, w := range want {
+		if events[i].RawLine != w {
+			t.Errorf("event %d: got RawLine %q, want %q", i, events[i].RawLine, w)
+		}
+	}
+	if data[vrclog.PlayerJoinData](events[1]).PlayerName != "User2" {
+		t.Errorf("event 1: got player %q, want User2", data[vrclog.PlayerJoinData](events[1]).PlayerName)
+	}
+}
+
+func TestParseFile_UdonException(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := "2024.01.15 12:00:00 Error      -  [UdonBehaviour] An exception occurred during Udon execution, this UdonBehaviour will be halted.\r\n" +
+		"VRC.Udon.VM.UdonVMException: The VM encountered an error!\r\n" +
+		"Exception Message:\r\n" +
+		"  Object reference not set to an instance of an object.\r\n" +
+		"\r\n" +
+		"  at VRC.Udon.VM.UdonVM.Interpret ()\r\n" +
+		"\r\n" +
+		"2024.01.15 12:00:01 Log        -  [Behaviour] OnPlayerJoined User1\r\n"
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := vrclog.ParseFileAll(context.Background(), logFile,
+		vrclog.WithParseIncludeTypes(vrclog.EventUdonException),
+	)
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+
+	d := data[vrclog.UdonExceptionData](events[0])
+	if d.ExceptionMessage != "Object reference not set to an instance of an object." {
+		t.Errorf("got ExceptionMessage %q", d.ExceptionMessage)
+	}
+	wantTrace := "VRC.Udon.VM.UdonVMException: The VM encountered an error!\n" +
+		"Exception Message:\n" +
+		"  Object reference not set to an instance of an object.\n" +
+		"  at VRC.Udon.VM.UdonVM.Interpret ()"
+	if d.StackTrace != wantTrace {
+		t.Errorf("got StackTrace %q, want %q", d.StackTrace, wantTrace)
+	}
+}
+
+func TestParseFile_AppLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := `2024.01.15 12:00:00 Log        -  VRChat Build: 2024.1.1p2-1409--Release
+2024.01.15 12:00:00 Log        -  Unity Version: 2022.3.6f1-DWR
+2024.01.15 12:00:00 Log        -  Using log file: output_log_test.txt
+2024.01.15 12:00:01 Log        -  XR Device: OpenXR Display
+2024.01.15 12:00:01 Log        -  Command line arguments: --profile=0
+2024.01.15 12:01:00 Log        -  [Behaviour] OnPlayerJoined TestUser
+2024.01.15 13:00:00 Debug      -  VRCApplication: OnApplicationQuit at 3600.5
+`
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := vrclog.ParseFileAll(context.Background(), logFile,
+		vrclog.WithParseIncludeRawLine(true),
+	)
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+
+	wantTypes := []vrclog.EventType{vrclog.EventAppStart, vrclog.EventPlayerJoin, vrclog.EventAppQuit}
+	if len(events) != len(wantTypes) {
+		t.Fatalf("got %d events, want %d", len(events), len(wantTypes))
+	}
+	for i, want := range wantTypes {
+		if events[i].Type != want {
+			t.Errorf("event %d: got type %q, want %q", i, events[i].Type, want)
+		}
+	}
+
+	start := events[0]
+	want := vrclog.AppStartData{
+		BuildVersion: "2024.1.1p2-1409--Release",
+		UnityVersion: "2022.3.6f1-DWR",
+		VRMode:       "vr",
+		CommandLine:  "--profile=0",
+	}
+	if start.Data != want {
+		t.Errorf("app_start = %+v", start)
+	}
+	wantTS, _ := time.ParseInLocation("2006.01.02 15:04:05", "2024.01.15 12:00:00", time.Local)
+	if !start.Timestamp.Equal(wantTS) {
+		t.Errorf("app_start timestamp = %v, want first line's", start.Timestamp)
+	}
+	if got := strings.Count(start.RawLine, "\n") + 1; got != 4 {
+		t.Errorf("app_start RawLine has %d lines, want 4", got)
+	}
+}
+
+func TestParseFile_AppStartAtEOF(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	// A log that only has client information (e.g. the client crashed)
+	content := "2024.01.15 12:00:00 Log        -  VRChat Build: 2024.1.1p2-1409--Release\n"
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := vrclog.ParseFileAll(context.Background(), logFile)
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != vrclog.EventAppStart {
+		t.Fatalf("got %+v, want one app_start", events)
+	}
+	if events[0].RawLine != "" {
+		t.Errorf("got RawLine %q without WithParseIncludeRawLine", events[0].RawLine)
+	}
+}
+
+func TestParseFile_ScreenshotWorldContext(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := `2024.01.15 12:00:00 Log        -  [VRC Camera] Took screenshot to: C:\shots\first.png
+2024.01.15 12:01:00 Log        -  [Behaviour] Joining wrld_12345678-1234-1234-1234-123456789abc:12345~region(jp)
+2024.01.15 12:01:01 Log        -  [Behaviour] Entering Room: Test World
+2024.01.15 12:02:00 Log        -  [VRC Camera] Took screenshot to: C:\shots\second.png
+`
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// world_join events are filtered out but must still set the context
+	events, err := vrclog.ParseFileAll(context.Background(), logFile,
+		vrclog.WithParseIncludeTypes(vrclog.EventScreenshot),
+	)
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+
+	if d := data[vrclog.ScreenshotData](events[0]); d.WorldID != "" || d.WorldName != "" {
+		t.Errorf("event 0: got world %q/%q, want none", d.WorldID, d.WorldName)
+	}
+
+	d := data[vrclog.ScreenshotData](events[1])
+	if d.ScreenshotPath != `C:\shots\second.png` {
+		t.Errorf("event 1: got path %q", d.ScreenshotPath)
+	}
+	if d.WorldID != "wrld_12345678-1234-1234-1234-123456789abc" ||
+		d.WorldName != "Test World" ||
+		d.InstanceID != "12345~region(jp)" {
+		t.Errorf("event 1: got world %q/%q/%q", d.WorldID, d.WorldName, d.InstanceID)
+	}
+}
+
+func TestParseFile_WorldJoinMerge(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := `2024.01.15 12:00:00 Log        -  [Behaviour] Entering Room: Test World
+2024.01.15 12:00:00 Log        -  [Behaviour] Joining wrld_12345678-1234-1234-1234-123456789abc:12345~group(grp_12345678-1234-1234-1234-123456789abc)
+2024.01.15 12:00:05 Log        -  [Behaviour] OnPlayerJoined TestUser
+2024.01.15 13:00:00 Log        -  [Behaviour] Entering Room: Lonely World
+`
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := vrclog.ParseFileAll(context.Background(), logFile, vrclog.WithParseIncludeRawLine(true))
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	var types []vrclog.EventType
+	for _, ev := range events {
+		types = append(types, ev.Type)
+	}
+	want := []vrclog.EventType{vrclog.EventWorldJoin, vrclog.EventGroupInstanceJoin, vrclog.EventPlayerJoin, vrclog.EventWorldJoin}
+	if !slices.Equal(types, want) {
+		t.Fatalf("got types %v, want %v", types, want)
+	}
+
+	// The two halves are merged into one world_join
+	for _, ev := range events[:2] {
+		d := data[vrclog.WorldJoinData](ev)
+		if ev.Type == vrclog.EventGroupInstanceJoin {
+			d = vrclog.WorldJoinData(data[vrclog.GroupInstanceJoinData](ev))
+		}
+		if d.WorldName != "Test World" || d.WorldID != "wrld_12345678-1234-1234-1234-123456789abc" ||
+			d.InstanceID == "" || d.Instance == nil || d.GroupID == "" {
+			t.Errorf("%s = %+v, want name, world, instance and group", ev.Type, ev)
+		}
+		if strings.Count(ev.RawLine, "\n") != 1 {
+			t.Errorf("%s RawLine = %q, want both lines", ev.Type, ev.RawLine)
+		}
+	}
+
+	// A half without its counterpart is emitted at end of file
+	if last := events[3]; last.Data != (vrclog.WorldJoinData{WorldName: "Lonely World"}) {
+		t.Errorf("last world_join = %+v, want name only", last)
+	}
+}
+
+func TestParseFile_WithLocation(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := "2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined TestUser\n"
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	want := time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)
+
+	events, err := vrclog.ParseFileAll(context.Background(), logFile, vrclog.WithParseLocation(ny))
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	if len(events) != 1 || !events[0].Timestamp.Equal(want) {
+		t.Fatalf("got %+v, want one event at %v", events, want)
+	}
+
+	for ev, err := range vrclog.ParseDir(context.Background(), vrclog.WithDirPaths(logFile), vrclog.WithDirLocation(ny)) {
+		if err != nil {
+			t.Fatalf("ParseDir error: %v", err)
+		}
+		if !ev.Timestamp.Equal(want) {
+			t.Errorf("ParseDir timestamp = %v, want %v", ev.Timestamp, want)
+		}
+	}
+}
+
+func TestParseFile_WithSource(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	lines := []string{
+		"2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined User1\r\n",
+		"2024.01.15 12:00:01 Error      -  Exception: boom\r\n",
+		"  at Foo.Bar ()\r\n",
+		"\r\n",
+		"2024.01.15 12:00:02 Log        -  [Behaviour] Joining wrld_12345678-1234-1234-1234-123456789abc:1~group(grp_12345678-1234-1234-1234-123456789abc)\n",
+	}
+	content := strings.Join(lines, "")
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	joinOffset := int64(strings.Index(content, "2024.01.15 12:00:02"))
+
+	events, err := vrclog.ParseFileAll(context.Background(), logFile, vrclog.WithParseSource(true))
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	want := []vrclog.Source{
+		{File: logFile, Line: 1, Offset: 0, Seq: 1},
+		{File: logFile, Line: 5, Offset: joinOffset, Seq: 2},
+		{File: logFile, Line: 5, Offset: joinOffset, Seq: 3}, // derived group_instance_join
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(events), len(want))
+	}
+	for i, ev := range events {
+		if ev.Source == nil {
+			t.Fatalf("event %d: Source is nil", i)
+		}
+		if *ev.Source != want[i] {
+			t.Errorf("event %d: Source = %+v, want %+v", i, *ev.Source, want[i])
+		}
+	}
+
+	// Source is only set when requested
+	events, err = vrclog.ParseFileAll(context.Background(), logFile)
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	for _, ev := range events {
+		if ev.Source != nil {
+			t.Errorf("Source = %+v without WithParseSource", ev.Source)
+		}
+	}
+}
+
+func TestParseFile_WorldJoinAcrossDownload(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := `2024.01.15 12:00:00 Log        -  [Behaviour] Joining wrld_12345678-1234-1234-1234-123456789abc:12345~region(jp)
+2024.01.15 12:00:01 Log        -  [AssetBundleDownloadManager] [3] Starting download of World wrld_12345678-1234-1234-1234-123456789abc
+2024.01.15 12:00:30 Log        -  [AssetBundleDownloadManager] [3] Finished download of World wrld_12345678-1234-1234-1234-123456789abc, 12845056 bytes
+2024.01.15 12:00:40 Log        -  [Behaviour] Entering Room: Test World
+`
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := vrclog.ParseFileAll(context.Background(), logFile)
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	var types []vrclog.EventType
+	for _, ev := range events {
+		types = append(types, ev.Type)
+	}
+	// The world_join is emitted once complete, after the downloads
+	want := []vrclog.EventType{vrclog.EventAssetDownload, vrclog.EventAssetDownload, vrclog.EventWorldJoin}
+	if !slices.Equal(types, want) {
+		t.Fatalf("got types %v, want %v", types, want)
+	}
+
+	join := events[2]
+	d := data[vrclog.WorldJoinData](join)
+	if d.WorldName != "Test World" || d.WorldID != "wrld_12345678-1234-1234-1234-123456789abc" || d.InstanceID != "12345~region(jp)" {
+		t.Errorf("world_join = %+v, want name and IDs", d)
+	}
+	if want := time.Date(2024, 1, 15, 12, 0, 0, 0, time.Local); !join.Timestamp.Equal(want) {
+		t.Errorf("world_join timestamp = %v, want the first half's %v", join.Timestamp, want)
+	}
+}
+
 func TestParseFile_ContextCancellation(t *testing.T) {
 	dir := t.TempDir()
 	logFile := filepath.Join(dir, "output_log_test.txt")
@@ -328,11 +729,11 @@ func TestParseDir_Basic(t *testing.T) {
 
 	// Should be in chronological order (by file modification time)
 	if len(events) >= 2 {
-		if events[0].PlayerName != "User1" {
-			t.Errorf("first event: got player %q, want User1", events[0].PlayerName)
+		if data[vrclog.PlayerJoinData](events[0]).PlayerName != "User1" {
+			t.Errorf("first event: got player %q, want User1", data[vrclog.PlayerJoinData](events[0]).PlayerName)
 		}
-		if events[1].PlayerName != "User2" {
-			t.Errorf("second event: got player %q, want User2", events[1].PlayerName)
+		if data[vrclog.PlayerJoinData](events[1]).PlayerName != "User2" {
+			t.Errorf("second event: got player %q, want User2", data[vrclog.PlayerJoinData](events[1]).PlayerName)
 		}
 	}
 }
@@ -368,6 +769,50 @@ func TestParseDir_WithPaths(t *testing.T) {
 	}
 }
 
+func TestParseDir_WithSource(t *testing.T) {
+	dir := t.TempDir()
+
+	logFile1 := filepath.Join(dir, "custom_log1.txt")
+	logFile2 := filepath.Join(dir, "custom_log2.txt")
+
+	content1 := "2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined User1\n"
+	content2 := "2024.01.15 13:00:00 Log        -  Unrelated\n" +
+		"2024.01.15 13:00:01 Log        -  [Behaviour] OnPlayerJoined User2\n"
+
+	if err := os.WriteFile(logFile1, []byte(content1), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(logFile2, []byte(content2), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []vrclog.Source{
+		{File: logFile1, Line: 1, Offset: 0, Seq: 1},
+		{File: logFile2, Line: 2, Offset: int64(strings.Index(content2, "\n") + 1), Seq: 2},
+	}
+
+	events := vrclog.ParseDir(context.Background(), vrclog.WithDirPaths(logFile1, logFile2), vrclog.WithDirSource(true))
+
+	// Sequence numbers restart on every iteration
+	for range 2 {
+		var got []vrclog.Source
+		for ev, err := range events {
+			if err != nil {
+				t.Fatalf("ParseDir error: %v", err)
+			}
+			got = append(got, *ev.Source)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("got %d events, want %d", len(got), len(want))
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("event %d: Source = %+v, want %+v", i, got[i], want[i])
+			}
+		}
+	}
+}
+
 func TestParseDir_EmptyDir(t *testing.T) {
 	dir := t.TempDir()
 
@@ -418,3 +863,39 @@ func TestParseDir_WithIncludeTypes(t *testing.T) {
 		t.Errorf("got type %v, want %v", events[0].Type, vrclog.EventPlayerJoin)
 	}
 }
+
+func BenchmarkParseFile(b *testing.B) {
+	// About 10 MB of log, one event per 20 lines
+	var sb strings.Builder
+	for i := range 100000 {
+		switch {
+		case i%20 == 0:
+			sb.WriteString("2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined TestUser (usr_12345678-1234-1234-1234-123456789abc)\n")
+		case i%20 == 10:
+			sb.WriteString("2024.01.15 12:00:00 Error      -  [Always] Exception in handler\n  at Foo.Bar ()\n  at Foo.Baz ()\n")
+		case i%2 == 0:
+			sb.WriteString("2024.01.15 12:00:00 Log        -  [Network Processing] Sending ping to server and waiting for a reply\n")
+		default:
+			sb.WriteString("2024.01.15 12:00:00 Debug      -  [Always] uSpeak: SetInputDevice 0 (3 total) 'Microphone (Realtek Audio)'\n")
+		}
+	}
+	logFile := filepath.Join(b.TempDir(), "output_log_bench.txt")
+	if err := os.WriteFile(logFile, []byte(sb.String()), 0644); err != nil {
+		b.Fatal(err)
+	}
+
+	b.SetBytes(int64(sb.Len()))
+	b.ReportAllocs()
+	for b.Loop() {
+		n := 0
+		for _, err := range vrclog.ParseFile(context.Background(), logFile) {
+			if err != nil {
+				b.Fatal(err)
+			}
+			n++
+		}
+		if n != 5000 {
+			b.Fatalf("got %d events, want 5000", n)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package vrclog
+
+import (
+	"strings"
+	"time"
+
+	"github.com/vrclog/vrclog-go/internal/parser"
+	"github.com/vrclog/vrclog-go/pkg/vrclog/event"
+)
+
+// Parser parses VRChat log lines into events.
+// Custom parsers extend the built-in parser with additional event types
+// and are registered per Watcher (WithParsers) or per parse call
+// (WithParseParsers, WithDirParsers).
+//
+// Parse follows the same contract as ParseLine:
+//   - (*Event, nil): Line was recognized
+//   - (nil, nil): Line is not handled by this parser
+//   - (nil, error): Line is handled by this parser but malformed
+//
+// The line passed to Parse is a whole log entry: the timestamped line
+// followed by any continuation lines (e.g. stack traces), joined by "\n",
+// with carriage returns removed. Patterns meant for the first line should
+// not rely on "$" or on "." matching newlines.
+type Parser interface {
+	Parse(line string) (*Event, error)
+}
+
+// ParserFunc adapts an ordinary function to the Parser interface.
+type ParserFunc func(line string) (*Event, error)
+
+// Parse calls f(line).
+func (f ParserFunc) Parse(line string) (*Event, error) {
+	return f(line)
+}
+
+// Matcher is an optional interface that a Parser can implement to cheaply
+// reject lines before Parse is called (e.g. with a strings.Contains check).
+// Match receives the same multi-line entry as Parse.
+// Parse is only invoked for lines where Match returns true.
+type Matcher interface {
+	Match(line string) bool
+}
+
+// RegisterEventType registers a custom event type name so that it is
+// accepted by event.ParseType, listed by event.TypeNames, and offered
+// by the CLI's --include-types/--exclude-types validation and completion.
+//
+// Registering an already-known name returns the existing type.
+// See event.Register for naming rules.
+//
+// Example:
+//
+//	var EventPortalSpawn = vrclog.MustRegisterEventType("portal_spawn")
+func RegisterEventType(name string) (EventType, error) {
+	return event.Register(name)
+}
+
+// MustRegisterEventType is like RegisterEventType but panics on error.
+func MustRegisterEventType(name string) EventType {
+	return event.MustRegister(name)
+}
+
+// parserChain runs the built-in parser followed by custom parsers.
+// The first parser that returns an event or an error wins.
+// The location only applies to the built-in parser; custom parsers set
+// their own timestamps.
+type parserChain []Parser
+
+func (c parserChain) parse(line string, loc *time.Location) (*Event, error) {
+	ev, err := parser.ParseInLocation(line, loc)
+	if err != nil || ev != nil || len(c) == 0 {
+		return ev, err
+	}
+
+	line = strings.TrimRight(line, "\r")
+	for _, p := range c {
+		if m, ok := p.(Matcher); ok && !m.Match(line) {
+			continue
+		}
+		ev, err := p.Parse(line)
+		if err != nil || ev != nil {
+			return ev, err
+		}
+	}
+	return nil, nil
+}
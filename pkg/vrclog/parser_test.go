@@ -0,0 +1,237 @@
+package vrclog_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vrclog/vrclog-go/pkg/vrclog"
+	"github.com/vrclog/vrclog-go/pkg/vrclog/event"
+)
+
+var eventTestCustom = vrclog.MustRegisterEventType("test_portal_spawn")
+
+// portalParser is a custom parser used in tests.
+// It implements both Parser and Matcher.
+type portalParser struct {
+	matchCalls int
+}
+
+var portalPattern = regexp.MustCompile(`\[Behaviour\] Portal spawned by (.+)`)
+
+func (p *portalParser) Match(line string) bool {
+	p.matchCalls++
+	return strings.Contains(line, "Portal spawned")
+}
+
+func (p *portalParser) Parse(line string) (*vrclog.Event, error) {
+	m := portalPattern.FindStringSubmatch(line)
+	if m == nil {
+		return nil, nil
+	}
+	return &vrclog.Event{Type: eventTestCustom, Data: portalData{PlayerName: m[1]}}, nil
+}
+
+// portalData is the payload of eventTestCustom events.
+type portalData struct {
+	PlayerName string `json:"player_name"`
+}
+
+func (portalData) EventType() vrclog.EventType { return eventTestCustom }
+
+func TestRegisterEventType(t *testing.T) {
+	if _, ok := event.ParseType("test_portal_spawn"); !ok {
+		t.Error("registered type not accepted by event.ParseType")
+	}
+	if _, err := vrclog.RegisterEventType("bad name"); err == nil {
+		t.Error("RegisterEventType() with invalid name should fail")
+	}
+}
+
+func TestParseFile_WithParsers(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := `2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined User1
+2024.01.15 12:00:01 Log        -  [Behaviour] Portal spawned by User1
+2024.01.15 12:00:02 Log        -  [Network] Unrelated line
+`
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &portalParser{}
+	events, err := vrclog.ParseFileAll(context.Background(), logFile,
+		vrclog.WithParseParsers(p),
+	)
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	if events[0].Type != vrclog.EventPlayerJoin {
+		t.Errorf("event 0: got type %v, want %v", events[0].Type, vrclog.EventPlayerJoin)
+	}
+	if events[1].Type != eventTestCustom || data[portalData](events[1]).PlayerName != "User1" {
+		t.Errorf("event 1: got %+v, want custom event for User1", events[1])
+	}
+
+	// Built-in matches short-circuit custom parsers
+	if p.matchCalls != 2 {
+		t.Errorf("Match called %d times, want 2", p.matchCalls)
+	}
+}
+
+func TestParseFile_WithParsersFilter(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := `2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined User1
+2024.01.15 12:00:01 Log        -  [Behaviour] Portal spawned by User1
+`
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := vrclog.ParseFileAll(context.Background(), logFile,
+		vrclog.WithParseParsers(&portalParser{}),
+		vrclog.WithParseIncludeTypes(eventTestCustom),
+	)
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+	if len(events) != 1 || events[0].Type != eventTestCustom {
+		t.Errorf("got %+v, want only custom event", events)
+	}
+}
+
+func TestParseFile_WithParsersError(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := "2024.01.15 12:00:00 Log        -  [Behaviour] Broken custom line\n"
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	errBroken := errors.New("broken")
+	failing := vrclog.ParserFunc(func(line string) (*vrclog.Event, error) {
+		if strings.Contains(line, "Broken") {
+			return nil, errBroken
+		}
+		return nil, nil
+	})
+
+	_, err := vrclog.ParseFileAll(context.Background(), logFile,
+		vrclog.WithParseParsers(failing),
+		vrclog.WithParseStopOnError(true),
+	)
+	var parseErr *vrclog.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("got error %v, want *ParseError", err)
+	}
+	if !errors.Is(err, errBroken) {
+		t.Errorf("got error %v, want wrapped %v", err, errBroken)
+	}
+}
+
+func TestParseFile_WithParsersMultiLine(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	content := "2024.01.15 12:00:01 Log        -  [Behaviour] Portal spawned by User1\r\n" +
+		"  continued\r\n"
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []string
+	p := vrclog.ParserFunc(func(line string) (*vrclog.Event, error) {
+		lines = append(lines, line)
+		return (&portalParser{}).Parse(line)
+	})
+	events, err := vrclog.ParseFileAll(context.Background(), logFile, vrclog.WithParseParsers(p))
+	if err != nil {
+		t.Fatalf("ParseFileAll error: %v", err)
+	}
+
+	// Parse receives the whole entry, without carriage returns
+	want := "2024.01.15 12:00:01 Log        -  [Behaviour] Portal spawned by User1\n  continued"
+	if len(lines) != 1 || lines[0] != want {
+		t.Errorf("Parse got %q, want [%q]", lines, want)
+	}
+	if len(events) != 1 || data[portalData](events[0]).PlayerName != "User1" {
+		t.Errorf("got %+v, want one custom event for User1", events)
+	}
+}
+
+func TestParseDir_WithParsers(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_2024-01-15_12-00-00.txt")
+
+	content := "2024.01.15 12:00:01 Log        -  [Behaviour] Portal spawned by User2\n"
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var events []vrclog.Event
+	for ev, err := range vrclog.ParseDir(context.Background(),
+		vrclog.WithDirLogDir(dir),
+		vrclog.WithDirParsers(&portalParser{}),
+	) {
+		if err != nil {
+			t.Fatalf("ParseDir error: %v", err)
+		}
+		events = append(events, ev)
+	}
+
+	if len(events) != 1 || data[portalData](events[0]).PlayerName != "User2" {
+		t.Errorf("got %+v, want one custom event for User2", events)
+	}
+}
+
+func TestWatcher_WithParsers(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	f, err := os.Create(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, errs, err := vrclog.WatchWithOptions(ctx,
+		vrclog.WithLogDir(dir),
+		vrclog.WithParsers(&portalParser{}),
+	)
+	if err != nil {
+		t.Fatalf("WatchWithOptions() error = %v", err)
+	}
+
+	// Give watcher time to start
+	time.Sleep(100 * time.Millisecond)
+
+	f.WriteString("2024.01.15 12:00:00 Log        -  [Behaviour] Portal spawned by User3\n")
+	f.Sync()
+
+	select {
+	case ev := <-events:
+		if ev.Type != eventTestCustom || data[portalData](ev).PlayerName != "User3" {
+			t.Errorf("got %+v, want custom event for User3", ev)
+		}
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for event")
+	}
+}
@@ -0,0 +1,298 @@
+package vrclog
+
+import (
+	"time"
+
+	"github.com/vrclog/vrclog-go/internal/parser"
+)
+
+// session tracks state that spans multiple events within one log file,
+// such as the local user and the world and instance they are currently in.
+// A zero session is ready to use; it is not safe for concurrent use.
+type session struct {
+	worldID    string
+	worldName  string
+	instanceID string
+
+	localName string
+	localID   string
+
+	// playerIDs maps the display names of players in the current instance
+	// to their user IDs, for player_left lines logged without the ID.
+	playerIDs map[string]string
+
+	// lastDownload maps a remote_download kind to its last requested URL,
+	// for failures that are logged without the URL.
+	lastDownload map[string]string
+
+	// assetStarts maps an asset ID to the time its download started,
+	// for computing asset_download durations.
+	assetStarts map[string]time.Time
+
+	// held is an app_start still merging the client information lines at
+	// the start of the log.
+	held *Event
+
+	// join is one half of a world_join waiting for the other.
+	join *Event
+
+	out []*Event // reused result buffer for observe and flush
+}
+
+// worldJoinWindow is how long, in log time, a world_join half waits for
+// the other. The world is downloaded and loaded between the two lines,
+// which can take minutes.
+const worldJoinWindow = 5 * time.Minute
+
+// observe updates the session from a log entry and its parsed event
+// (nil if the entry is not a recognized event), and annotates ev with the
+// session state it depends on. It must be called for every entry, before
+// any filtering, so that filtered-out events still update the state.
+//
+// Returns the events to emit, in order: usually just ev; nothing if ev is
+// held back to be merged with later lines; and possibly previously held
+// events released by this entry. RawLine is set on all returned events. The
+// returned slice is only valid until the next call to observe or flush.
+func (s *session) observe(entry string, ev *Event) []*Event {
+	s.out = s.out[:0]
+
+	if ev == nil {
+		// The local player marker is not an event of its own
+		if name, ok := parser.ParseLocalPlayer(entry); ok {
+			s.localName = name
+		}
+		return s.out
+	}
+	ev.RawLine = entry
+
+	if s.join != nil && ev.Timestamp.Sub(s.join.Timestamp) > worldJoinWindow {
+		s.releaseJoin()
+	}
+	switch d := ev.Data.(type) {
+	case AppStartData:
+		s.holdAppStart(ev, d)
+		return s.out
+	case WorldJoinData:
+		s.releaseHeld()
+		s.observeWorldJoin(ev, d)
+		return s.out
+	case WorldLeaveData:
+		s.releaseJoin()
+	}
+	s.releaseHeld()
+
+	// Payloads are values: changes are stored back into ev.Data
+	switch d := ev.Data.(type) {
+	case SelfAuthenticatedData:
+		s.localName = d.PlayerName
+		s.localID = d.PlayerID
+	case WorldLeaveData:
+		d.WorldID, d.WorldName, d.InstanceID = s.worldID, s.worldName, s.instanceID
+		ev.Data = d
+		s.worldID, s.worldName, s.instanceID = "", "", ""
+		clear(s.playerIDs)
+	case PlayerJoinData:
+		if d.PlayerID != "" {
+			if s.playerIDs == nil {
+				s.playerIDs = make(map[string]string)
+			}
+			s.playerIDs[d.PlayerName] = d.PlayerID
+		}
+		d.IsLocal = s.isLocal(d.PlayerName, d.PlayerID)
+		ev.Data = d
+	case PlayerLeftData:
+		if d.PlayerID == "" {
+			d.PlayerID = s.playerIDs[d.PlayerName]
+		}
+		delete(s.playerIDs, d.PlayerName)
+		d.IsLocal = s.isLocal(d.PlayerName, d.PlayerID)
+		ev.Data = d
+	case ScreenshotData:
+		d.WorldID, d.WorldName, d.InstanceID = s.worldID, s.worldName, s.instanceID
+		ev.Data = d
+	case RemoteDownloadData:
+		if d.DownloadURL != "" && d.DownloadStatus == "requested" {
+			if s.lastDownload == nil {
+				s.lastDownload = make(map[string]string)
+			}
+			s.lastDownload[d.DownloadKind] = d.DownloadURL
+		} else if d.DownloadURL == "" {
+			d.DownloadURL = s.lastDownload[d.DownloadKind]
+		}
+		d.WorldID, d.WorldName, d.InstanceID = s.worldID, s.worldName, s.instanceID
+		ev.Data = d
+	case AssetDownloadData:
+		s.observeAssetDownload(ev.Timestamp, &d)
+		ev.Data = d
+	}
+	return append(s.out, ev)
+}
+
+// observeAssetDownload records asset download starts and sets DurationMS
+// on completions and failures. A failure ends tracking of the asset; a
+// completion does not, since loading the unpacked bundle may still fail.
+func (s *session) observeAssetDownload(ts time.Time, d *AssetDownloadData) {
+	switch d.DownloadStatus {
+	case "started":
+		if s.assetStarts == nil {
+			s.assetStarts = make(map[string]time.Time)
+		}
+		s.assetStarts[d.AssetID] = ts
+	case "completed", "failed":
+		if start, ok := s.assetStarts[d.AssetID]; ok {
+			d.DurationMS = ts.Sub(start).Milliseconds()
+		}
+		if d.DownloadStatus == "failed" {
+			delete(s.assetStarts, d.AssetID)
+		}
+	}
+}
+
+// flush releases held events, returning the events to emit. Callers
+// flush with final set at the end of a log file, releasing everything, and
+// whenever the log goes quiet, releasing only the app_start: a world_join
+// half waits for the other through the download of the world. The returned
+// slice is only valid until the next call to observe or flush.
+func (s *session) flush(final bool) []*Event {
+	s.out = s.out[:0]
+	if final {
+		s.releaseJoin()
+	}
+	s.releaseHeld()
+	return s.out
+}
+
+// releaseHeld appends the held app_start, if any, to s.out.
+func (s *session) releaseHeld() {
+	if s.held != nil {
+		s.out = append(s.out, s.held)
+		s.held = nil
+	}
+}
+
+// releaseJoin appends the held world_join half, if any, to s.out, followed
+// by the group_instance_join derived from a join of a group instance.
+func (s *session) releaseJoin() {
+	join := s.join
+	if join == nil {
+		return
+	}
+	s.join = nil
+	s.out = append(s.out, join)
+	if d := join.Data.(WorldJoinData); d.GroupID != "" {
+		// Derived event for group attendance tracking
+		group := *join
+		group.Type = EventGroupInstanceJoin
+		group.Data = GroupInstanceJoinData(d)
+		s.out = append(s.out, &group)
+	}
+}
+
+// holdAppStart merges an app_start event (carrying one piece of client
+// information) into the held app_start. If the held event already has
+// that information, the client was restarted: the held event is released
+// and ev is held instead.
+func (s *session) holdAppStart(ev *Event, d AppStartData) {
+	if s.held != nil {
+		if held, ok := s.held.Data.(AppStartData); ok && mergeAppStart(&held, d) {
+			s.held.Data = held
+			s.held.RawLine += "\n" + ev.RawLine
+			return
+		}
+	}
+	s.releaseHeld()
+	s.held = ev
+}
+
+// observeWorldJoin tracks the world being joined and merges the two
+// world_join events logged per join: "Joining" with the world and instance
+// IDs and "Entering Room" with the world name. The first half is held until
+// the other arrives; other events, which may be logged in between (e.g.
+// downloads of the world), do not release it. A half is released on its own
+// by the next world_join it does not pair with, by a world_leave, at the
+// end of the log file, or once worldJoinWindow has passed in log time.
+func (s *session) observeWorldJoin(ev *Event, d WorldJoinData) {
+	if s.join != nil {
+		if held, ok := s.join.Data.(WorldJoinData); ok && mergeWorldJoin(&held, d) {
+			s.join.Data = held
+			s.join.RawLine += "\n" + ev.RawLine
+			s.setWorld(held)
+			s.releaseComplete()
+			return
+		}
+	}
+	s.releaseJoin()
+
+	// A new join: events until the other half have only this half's context
+	s.setWorld(d)
+	clear(s.playerIDs)
+	s.join = ev
+	// Already complete, e.g. from a custom parser
+	s.releaseComplete()
+}
+
+// releaseComplete releases the held world_join if it has both halves.
+func (s *session) releaseComplete() {
+	if d := s.join.Data.(WorldJoinData); d.WorldID != "" && d.WorldName != "" {
+		s.releaseJoin()
+	}
+}
+
+// setWorld sets the current world from a (possibly partial) world_join.
+func (s *session) setWorld(d WorldJoinData) {
+	s.worldID, s.worldName, s.instanceID = d.WorldID, d.WorldName, d.InstanceID
+}
+
+// mergeWorldJoin copies the half of a world join that dst lacks from src:
+// the world name, or the world and instance IDs. Halves are paired on the
+// IDs: a repeated half with the same IDs is absorbed, and a half with other
+// IDs belongs to another join. It reports false, leaving dst unchanged, if
+// src is not the other half.
+func mergeWorldJoin(dst *WorldJoinData, src WorldJoinData) bool {
+	switch {
+	case src.WorldID != "" && dst.WorldID != "":
+		// A repeated "Joining" line for the same instance
+		return src.WorldName == "" && src.WorldID == dst.WorldID && src.InstanceID == dst.InstanceID
+	case dst.WorldName == "" && src.WorldName != "" && src.WorldID == "":
+		dst.WorldName = src.WorldName
+	case dst.WorldID == "" && src.WorldID != "" && src.WorldName == "":
+		dst.WorldID = src.WorldID
+		dst.InstanceID = src.InstanceID
+		dst.Instance = src.Instance
+		dst.GroupID = src.GroupID
+	default:
+		return false
+	}
+	return true
+}
+
+// mergeAppStart copies the client information of src into dst.
+// It reports false, leaving dst unchanged, if a field is set in both.
+func mergeAppStart(dst *AppStartData, src AppStartData) bool {
+	fields := []struct{ dst, src *string }{
+		{&dst.BuildVersion, &src.BuildVersion},
+		{&dst.UnityVersion, &src.UnityVersion},
+		{&dst.VRMode, &src.VRMode},
+		{&dst.CommandLine, &src.CommandLine},
+	}
+	for _, f := range fields {
+		if *f.dst != "" && *f.src != "" {
+			return false
+		}
+	}
+	for _, f := range fields {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	return true
+}
+
+// isLocal reports whether the player is the local user. The user ID is
+// preferred when both sides have one, since display names can change.
+func (s *session) isLocal(name, id string) bool {
+	if id != "" && s.localID != "" {
+		return id == s.localID
+	}
+	return name != "" && name == s.localName
+}
@@ -0,0 +1,424 @@
+package vrclog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSession_Observe(t *testing.T) {
+	var s session
+
+	// Screenshot before any world_join has no world context
+	shot := Event{Type: EventScreenshot, Data: ScreenshotData{ScreenshotPath: "a.png"}}
+	s.observe("", &shot)
+	if d := shot.Data.(ScreenshotData); d.WorldID != "" || d.WorldName != "" || d.InstanceID != "" {
+		t.Errorf("screenshot before join = %+v, want no world context", shot)
+	}
+
+	s.observe("", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldID: "wrld_1", InstanceID: "123~region(jp)"}})
+	s.observe("", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldName: "First World"}})
+
+	shot = Event{Type: EventScreenshot, Data: ScreenshotData{ScreenshotPath: "b.png"}}
+	s.observe("", &shot)
+	if d := shot.Data.(ScreenshotData); d.WorldID != "wrld_1" || d.WorldName != "First World" || d.InstanceID != "123~region(jp)" {
+		t.Errorf("screenshot in first world = %+v", shot)
+	}
+
+	s.observe("", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldID: "wrld_2", InstanceID: "456"}})
+	s.observe("", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldName: "Second World"}})
+
+	shot = Event{Type: EventScreenshot, Data: ScreenshotData{ScreenshotPath: "c.png"}}
+	s.observe("", &shot)
+	if d := shot.Data.(ScreenshotData); d.WorldID != "wrld_2" || d.WorldName != "Second World" || d.InstanceID != "456" {
+		t.Errorf("screenshot in second world = %+v", shot)
+	}
+
+	// Other events are not annotated
+	join := Event{Type: EventPlayerJoin, Data: PlayerJoinData{PlayerName: "TestUser"}}
+	s.observe("", &join)
+	if join.Data != (PlayerJoinData{PlayerName: "TestUser"}) {
+		t.Errorf("player_join annotated: %+v", join.Data)
+	}
+}
+
+func TestSession_LocalPlayer(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(s *session)
+		ev    Event
+		want  bool
+	}{
+		{
+			name:  "unknown local user",
+			setup: func(s *session) {},
+			ev:    Event{Type: EventPlayerJoin, Data: PlayerJoinData{PlayerName: "Me"}},
+			want:  false,
+		},
+		{
+			name: "matched by authenticated name",
+			setup: func(s *session) {
+				s.observe("", &Event{Type: EventSelfAuthenticated, Data: SelfAuthenticatedData{PlayerName: "Me", PlayerID: "usr_1"}})
+			},
+			ev:   Event{Type: EventPlayerJoin, Data: PlayerJoinData{PlayerName: "Me"}},
+			want: true,
+		},
+		{
+			name: "matched by authenticated ID",
+			setup: func(s *session) {
+				s.observe("", &Event{Type: EventSelfAuthenticated, Data: SelfAuthenticatedData{PlayerName: "Old Name", PlayerID: "usr_1"}})
+			},
+			ev:   Event{Type: EventPlayerJoin, Data: PlayerJoinData{PlayerName: "Me", PlayerID: "usr_1"}},
+			want: true,
+		},
+		{
+			name: "other player",
+			setup: func(s *session) {
+				s.observe("", &Event{Type: EventSelfAuthenticated, Data: SelfAuthenticatedData{PlayerName: "Me", PlayerID: "usr_1"}})
+			},
+			ev:   Event{Type: EventPlayerJoin, Data: PlayerJoinData{PlayerName: "Someone", PlayerID: "usr_2"}},
+			want: false,
+		},
+		{
+			name: "matched by local PlayerAPI line",
+			setup: func(s *session) {
+				s.observe(`2024.01.15 12:00:00 Log        -  [Behaviour] Initialized PlayerAPI "Me" is local`, nil)
+			},
+			ev:   Event{Type: EventPlayerLeft, Data: PlayerLeftData{PlayerName: "Me"}},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s session
+			tt.setup(&s)
+			ev := tt.ev
+			s.observe("", &ev)
+			var isLocal bool
+			switch d := ev.Data.(type) {
+			case PlayerJoinData:
+				isLocal = d.IsLocal
+			case PlayerLeftData:
+				isLocal = d.IsLocal
+			}
+			if isLocal != tt.want {
+				t.Errorf("IsLocal = %v, want %v", isLocal, tt.want)
+			}
+		})
+	}
+}
+
+func TestSession_WorldLeave(t *testing.T) {
+	var s session
+
+	s.observe("", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldID: "wrld_1", InstanceID: "123"}})
+	s.observe("", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldName: "First World"}})
+
+	leave := Event{Type: EventWorldLeave, Data: WorldLeaveData{}}
+	if len(s.observe("", &leave)) != 1 {
+		t.Fatal("first world_leave should be kept")
+	}
+	if leave.Data != (WorldLeaveData{WorldID: "wrld_1", WorldName: "First World", InstanceID: "123"}) {
+		t.Errorf("world_leave = %+v, want world being left", leave)
+	}
+
+	// No world context between leave and the next join
+	shot := Event{Type: EventScreenshot, Data: ScreenshotData{}}
+	s.observe("", &shot)
+	if d := shot.Data.(ScreenshotData); d.WorldID != "" || d.WorldName != "" {
+		t.Errorf("screenshot after leave = %+v, want no world context", shot)
+	}
+
+	s.observe("", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldID: "wrld_2", InstanceID: "456"}})
+	got := s.observe("", &Event{Type: EventWorldLeave, Data: WorldLeaveData{}})
+	if len(got) != 2 || got[1].Type != EventWorldLeave {
+		t.Errorf("got %v, want [world_join, world_leave] after a new join", got)
+	}
+}
+
+func TestSession_AppStart(t *testing.T) {
+	var s session
+
+	// Client information lines are merged into one held app_start
+	parts := []Event{
+		{Type: EventAppStart, Data: AppStartData{BuildVersion: "build-1"}},
+		{Type: EventAppStart, Data: AppStartData{UnityVersion: "2022.3"}},
+		{Type: EventAppStart, Data: AppStartData{VRMode: "desktop"}},
+	}
+	for i := range parts {
+		if got := s.observe("line", &parts[i]); len(got) != 0 {
+			t.Fatalf("part %d: got %d events, want 0 (held)", i, len(got))
+		}
+	}
+
+	// The next event releases the held app_start first
+	join := Event{Type: EventPlayerJoin, Data: PlayerJoinData{PlayerName: "TestUser"}}
+	got := s.observe("join", &join)
+	if len(got) != 2 || got[0].Type != EventAppStart || got[1] != &join {
+		t.Fatalf("got %v, want [app_start, player_join]", got)
+	}
+	start := got[0]
+	if start.Data != (AppStartData{BuildVersion: "build-1", UnityVersion: "2022.3", VRMode: "desktop"}) {
+		t.Errorf("merged app_start = %+v", start)
+	}
+	if start.RawLine != "line\nline\nline" {
+		t.Errorf("merged RawLine = %q", start.RawLine)
+	}
+
+	// A repeated field starts a new app_start (client restart)
+	s.observe("", &Event{Type: EventAppStart, Data: AppStartData{BuildVersion: "build-1"}})
+	got = s.observe("", &Event{Type: EventAppStart, Data: AppStartData{BuildVersion: "build-2"}})
+	if len(got) != 1 || got[0].Data.(AppStartData).BuildVersion != "build-1" {
+		t.Fatalf("got %v, want released build-1 app_start", got)
+	}
+	if held := s.flush(false); len(held) != 1 || held[0].Data.(AppStartData).BuildVersion != "build-2" {
+		t.Errorf("flush(false) = %v, want build-2 app_start", held)
+	}
+	if held := s.flush(true); len(held) != 0 {
+		t.Errorf("second flush(true) = %v, want nothing", held)
+	}
+}
+
+func TestSession_RemoteDownload(t *testing.T) {
+	var s session
+	s.observe("", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldID: "wrld_1", InstanceID: "123"}})
+
+	req := Event{Type: EventRemoteDownload, Data: RemoteDownloadData{DownloadKind: "image", DownloadURL: "https://example.com/a.png", DownloadStatus: "requested"}}
+	s.observe("", &req)
+	if d := req.Data.(RemoteDownloadData); d.WorldID != "wrld_1" || d.InstanceID != "123" {
+		t.Errorf("request = %+v, want world context", req)
+	}
+
+	// A string request does not affect image failures
+	s.observe("", &Event{Type: EventRemoteDownload, Data: RemoteDownloadData{DownloadKind: "string", DownloadURL: "https://example.com/s", DownloadStatus: "requested"}})
+
+	fail := Event{Type: EventRemoteDownload, Data: RemoteDownloadData{DownloadKind: "image", DownloadStatus: "failed", DownloadError: "too large"}}
+	s.observe("", &fail)
+	d := fail.Data.(RemoteDownloadData)
+	if d.DownloadURL != "https://example.com/a.png" {
+		t.Errorf("failure URL = %q, want last image URL", d.DownloadURL)
+	}
+	if d.WorldID != "wrld_1" {
+		t.Errorf("failure world = %q, want wrld_1", d.WorldID)
+	}
+}
+
+func TestSession_GroupInstanceJoin(t *testing.T) {
+	var s session
+
+	// Non-group instances produce only world_join
+	s.observe("", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldName: "Public World"}})
+	if got := s.observe("", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldID: "wrld_1", InstanceID: "123"}}); len(got) != 1 {
+		t.Fatalf("public join: got %d events, want 1", len(got))
+	}
+
+	name := Event{Type: EventWorldJoin, Data: WorldJoinData{WorldName: "Group World"}}
+	s.observe("name", &name)
+	got := s.observe("id", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldID: "wrld_2", InstanceID: "456~group(grp_1)", GroupID: "grp_1"}})
+	if len(got) != 2 {
+		t.Fatalf("group join: got %d events, want 2", len(got))
+	}
+	if got[0] != &name {
+		t.Errorf("first event = %+v, want the merged world_join", got[0])
+	}
+	group := got[1]
+	want := GroupInstanceJoinData{WorldID: "wrld_2", WorldName: "Group World", InstanceID: "456~group(grp_1)", GroupID: "grp_1"}
+	if group.Type != EventGroupInstanceJoin || group.Data != want || group.RawLine != "name\nid" {
+		t.Errorf("second event = %+v, want group_instance_join copy", group)
+	}
+}
+
+func TestSession_WorldJoinMerge(t *testing.T) {
+	name := func() *Event { return &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldName: "Test World"}} }
+	id := func() *Event {
+		return &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldID: "wrld_1", InstanceID: "123~region(jp)", Instance: &InstanceInfo{Name: "123"}}}
+	}
+	complete := func(ev *Event) bool {
+		d, ok := ev.Data.(WorldJoinData)
+		return ok && ev.Type == EventWorldJoin && d.WorldName == "Test World" && d.WorldID == "wrld_1" &&
+			d.InstanceID == "123~region(jp)" && d.Instance != nil
+	}
+	data := func(ev *Event) WorldJoinData { return ev.Data.(WorldJoinData) }
+
+	t.Run("name then ID", func(t *testing.T) {
+		var s session
+		if got := s.observe("a", name()); len(got) != 0 {
+			t.Fatalf("first half: got %v, want held", got)
+		}
+		got := s.observe("b", id())
+		if len(got) != 1 || !complete(got[0]) || got[0].RawLine != "a\nb" {
+			t.Fatalf("got %v, want one complete world_join", got)
+		}
+	})
+
+	t.Run("ID then name", func(t *testing.T) {
+		var s session
+		s.observe("", id())
+		got := s.observe("", name())
+		if len(got) != 1 || !complete(got[0]) {
+			t.Fatalf("got %v, want one complete world_join", got)
+		}
+	})
+
+	t.Run("other events do not release half", func(t *testing.T) {
+		var s session
+		s.observe("", id())
+		download := &Event{Type: EventAssetDownload, Data: AssetDownloadData{DownloadKind: "world", AssetID: "wrld_1", DownloadStatus: "started"}}
+		shot := &Event{Type: EventScreenshot, Data: ScreenshotData{}}
+		for _, ev := range []*Event{download, shot} {
+			if got := s.observe("", ev); len(got) != 1 || got[0] != ev {
+				t.Fatalf("got %v, want only the %s", got, ev.Type)
+			}
+		}
+		if d := shot.Data.(ScreenshotData); d.WorldID != "wrld_1" || d.WorldName != "" {
+			t.Errorf("screenshot = %+v, want the context of the ID half only", shot)
+		}
+		if got := s.flush(false); len(got) != 0 {
+			t.Fatalf("flush(false) = %v, want the half still held", got)
+		}
+		if got := s.observe("", name()); len(got) != 1 || !complete(got[0]) {
+			t.Fatalf("got %v, want one complete world_join", got)
+		}
+	})
+
+	t.Run("same half twice", func(t *testing.T) {
+		var s session
+		s.observe("a", id())
+		if got := s.observe("b", id()); len(got) != 0 {
+			t.Fatalf("got %v, want the repeated half absorbed", got)
+		}
+		got := s.observe("c", name())
+		if len(got) != 1 || !complete(got[0]) || got[0].RawLine != "a\nb\nc" {
+			t.Fatalf("got %v, want one complete world_join", got)
+		}
+	})
+
+	t.Run("half of another join", func(t *testing.T) {
+		var s session
+		s.observe("", id())
+		other := &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldID: "wrld_2", InstanceID: "456"}}
+		got := s.observe("", other)
+		if len(got) != 1 || data(got[0]).WorldID != "wrld_1" || data(got[0]).WorldName != "" {
+			t.Fatalf("got %v, want the first ID-only world_join", got)
+		}
+		if got := s.flush(true); len(got) != 1 || got[0] != other {
+			t.Errorf("flush(true) = %v, want the second ID-only world_join", got)
+		}
+	})
+
+	t.Run("world_leave releases half", func(t *testing.T) {
+		var s session
+		s.observe("", name())
+		leave := &Event{Type: EventWorldLeave, Data: WorldLeaveData{}}
+		got := s.observe("", leave)
+		if len(got) != 2 || data(got[0]).WorldName != "Test World" || data(got[0]).WorldID != "" || got[1] != leave {
+			t.Fatalf("got %v, want [name-only world_join, world_leave]", got)
+		}
+	})
+
+	t.Run("window releases half", func(t *testing.T) {
+		var s session
+		base := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+		half := id()
+		half.Timestamp = base
+		s.observe("", half)
+		join := &Event{Type: EventPlayerJoin, Timestamp: base.Add(worldJoinWindow), Data: PlayerJoinData{PlayerName: "TestUser"}}
+		if got := s.observe("", join); len(got) != 1 || got[0] != join {
+			t.Fatalf("got %v at the window, want only the player_join", got)
+		}
+		late := name()
+		late.Timestamp = base.Add(worldJoinWindow + time.Second)
+		got := s.observe("", late)
+		if len(got) != 1 || got[0] != half || data(got[0]).WorldName != "" {
+			t.Fatalf("got %v after the window, want the ID-only world_join", got)
+		}
+	})
+
+	t.Run("final flush releases half", func(t *testing.T) {
+		var s session
+		s.observe("", id())
+		if got := s.flush(true); len(got) != 1 || data(got[0]).WorldID != "wrld_1" {
+			t.Fatalf("flush(true) = %v, want ID-only world_join", got)
+		}
+	})
+
+	t.Run("complete join is not held", func(t *testing.T) {
+		var s session
+		ev := id()
+		d := data(ev)
+		d.WorldName = "Test World"
+		ev.Data = d
+		if got := s.observe("", ev); len(got) != 1 || !complete(got[0]) {
+			t.Fatalf("got %v, want the world_join", got)
+		}
+	})
+
+	t.Run("context from merged join", func(t *testing.T) {
+		var s session
+		s.observe("", name())
+		s.observe("", id())
+		shot := &Event{Type: EventScreenshot, Data: ScreenshotData{}}
+		s.observe("", shot)
+		if d := shot.Data.(ScreenshotData); d.WorldID != "wrld_1" || d.WorldName != "Test World" {
+			t.Errorf("screenshot = %+v, want world context", shot)
+		}
+	})
+}
+
+func TestSession_AssetDownload(t *testing.T) {
+	var s session
+	base := time.Date(2024, 1, 15, 12, 0, 0, 0, time.Local)
+	observe := func(status string, offset time.Duration) AssetDownloadData {
+		ev := &Event{Type: EventAssetDownload, Timestamp: base.Add(offset), Data: AssetDownloadData{DownloadKind: "world", AssetID: "wrld_1", DownloadStatus: status}}
+		s.observe("", ev)
+		return ev.Data.(AssetDownloadData)
+	}
+
+	// No start seen: no duration
+	if ev := observe("completed", 0); ev.DurationMS != 0 {
+		t.Errorf("completed without start: DurationMS = %d, want 0", ev.DurationMS)
+	}
+
+	observe("started", 0)
+	if ev := observe("unpacking", 90*time.Second); ev.DurationMS != 0 {
+		t.Errorf("unpacking: DurationMS = %d, want 0", ev.DurationMS)
+	}
+	if ev := observe("completed", 2*time.Minute); ev.DurationMS != 120000 {
+		t.Errorf("completed: DurationMS = %d, want 120000", ev.DurationMS)
+	}
+	if ev := observe("failed", 2*time.Minute+5*time.Second); ev.DurationMS != 125000 {
+		t.Errorf("failed after completion: DurationMS = %d, want 125000", ev.DurationMS)
+	}
+	if ev := observe("failed", 3*time.Minute); ev.DurationMS != 0 {
+		t.Errorf("second failure: DurationMS = %d, want 0", ev.DurationMS)
+	}
+}
+
+func TestSession_PlayerLeftID(t *testing.T) {
+	var s session
+	s.observe("", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldID: "wrld_1", InstanceID: "1"}})
+	s.observe("", &Event{Type: EventPlayerJoin, Data: PlayerJoinData{PlayerName: "Alice", PlayerID: "usr_a"}})
+	s.observe("", &Event{Type: EventPlayerJoin, Data: PlayerJoinData{PlayerName: "Bob"}})
+
+	left := Event{Type: EventPlayerLeft, Data: PlayerLeftData{PlayerName: "Alice"}}
+	s.observe("", &left)
+	if id := left.Data.(PlayerLeftData).PlayerID; id != "usr_a" {
+		t.Errorf("PlayerID = %q, want usr_a from the join", id)
+	}
+
+	// A logged ID is kept as is
+	left = Event{Type: EventPlayerLeft, Data: PlayerLeftData{PlayerName: "Bob", PlayerID: "usr_b"}}
+	s.observe("", &left)
+	if id := left.Data.(PlayerLeftData).PlayerID; id != "usr_b" {
+		t.Errorf("PlayerID = %q, want usr_b", id)
+	}
+
+	// IDs do not carry over to the next instance
+	s.observe("", &Event{Type: EventPlayerJoin, Data: PlayerJoinData{PlayerName: "Carol", PlayerID: "usr_c"}})
+	s.observe("", &Event{Type: EventWorldJoin, Data: WorldJoinData{WorldID: "wrld_2", InstanceID: "2"}})
+	left = Event{Type: EventPlayerLeft, Data: PlayerLeftData{PlayerName: "Carol"}}
+	s.observe("", &left)
+	if id := left.Data.(PlayerLeftData).PlayerID; id != "" {
+		t.Errorf("PlayerID = %q after instance change, want empty", id)
+	}
+}
@@ -0,0 +1,47 @@
+package vrclog
+
+import "iter"
+
+// linePos is the position of a line in a log file.
+type linePos struct {
+	line   int   // 1-based line number, 0 if unknown
+	offset int64 // byte offset of the start of the line
+}
+
+// source returns the Source of an entry starting at p in file. Seq is set
+// later, when the event is emitted.
+func (p linePos) source(file string) *Source {
+	return &Source{File: file, Line: p.line, Offset: p.offset}
+}
+
+// sequence numbers the events of one stream. The zero value starts at 1.
+type sequence uint64
+
+// stamp sets ev.Source.Seq to the next number, if ev has a Source.
+// The Source is copied first, since events derived from the same entry
+// share it.
+func (s *sequence) stamp(ev *Event) {
+	if ev.Source == nil {
+		return
+	}
+	*s++
+	src := *ev.Source
+	src.Seq = uint64(*s)
+	ev.Source = &src
+}
+
+// numbered stamps sequence numbers on the events of events, starting
+// from 1 on every iteration.
+func numbered(events iter.Seq2[Event, error]) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		var seq sequence
+		for ev, err := range events {
+			if err == nil {
+				seq.stamp(&ev)
+			}
+			if !yield(ev, err) {
+				return
+			}
+		}
+	}
+}
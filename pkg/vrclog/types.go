@@ -1,6 +1,10 @@
 package vrclog
 
-import "github.com/vrclog/vrclog-go/pkg/vrclog/event"
+import (
+	"time"
+
+	"github.com/vrclog/vrclog-go/pkg/vrclog/event"
+)
 
 // Re-export event types for convenience.
 // Users can import just "github.com/vrclog/vrclog-go/pkg/vrclog"
@@ -14,7 +18,118 @@ type EventType = event.Type
 
 // Event type constants.
 const (
-	EventWorldJoin  = event.WorldJoin
-	EventPlayerJoin = event.PlayerJoin
-	EventPlayerLeft = event.PlayerLeft
+	EventWorldJoin         = event.WorldJoin
+	EventPlayerJoin        = event.PlayerJoin
+	EventPlayerLeft        = event.PlayerLeft
+	EventAvatarChange      = event.AvatarChange
+	EventVideoPlay         = event.VideoPlay
+	EventScreenshot        = event.Screenshot
+	EventSelfAuthenticated = event.SelfAuthenticated
+	EventWorldLeave        = event.WorldLeave
+	EventDisconnect        = event.Disconnect
+	EventNotification      = event.Notification
+	EventUdonException     = event.UdonException
+	EventAppStart          = event.AppStart
+	EventAppQuit           = event.AppQuit
+	EventRemoteDownload    = event.RemoteDownload
+	EventPortalDrop        = event.PortalDrop
+	EventStickerSpawn      = event.StickerSpawn
+	EventEmojiSpawn        = event.EmojiSpawn
+	EventPrintPlace        = event.PrintPlace
+	EventModeration        = event.Moderation
+	EventGroupInstanceJoin = event.GroupInstanceJoin
+	EventGroupNotification = event.GroupNotification
+	EventAssetDownload     = event.AssetDownload
+	EventUnrecognized      = event.Unrecognized
 )
+
+// Source locates the log entry an event was parsed from.
+type Source = event.Source
+
+// Data is the type-specific payload of an Event, stored in Event.Data.
+// See event.Data.
+type Data = event.Data
+
+// TypedEvent is the typed form of an Event, with the type-specific fields
+// under "data" in JSON. See Event.Typed.
+type TypedEvent = event.TypedEvent
+
+// Event payload types, one per built-in event type.
+type (
+	WorldJoinData         = event.WorldJoinData
+	GroupInstanceJoinData = event.GroupInstanceJoinData
+	WorldLeaveData        = event.WorldLeaveData
+	PlayerJoinData        = event.PlayerJoinData
+	PlayerLeftData        = event.PlayerLeftData
+	SelfAuthenticatedData = event.SelfAuthenticatedData
+	AvatarChangeData      = event.AvatarChangeData
+	VideoPlayData         = event.VideoPlayData
+	ScreenshotData        = event.ScreenshotData
+	DisconnectData        = event.DisconnectData
+	NotificationData      = event.NotificationData
+	GroupNotificationData = event.GroupNotificationData
+	UdonExceptionData     = event.UdonExceptionData
+	AppStartData          = event.AppStartData
+	AppQuitData           = event.AppQuitData
+	RemoteDownloadData    = event.RemoteDownloadData
+	AssetDownloadData     = event.AssetDownloadData
+	PortalDropData        = event.PortalDropData
+	StickerSpawnData      = event.StickerSpawnData
+	EmojiSpawnData        = event.EmojiSpawnData
+	PrintPlaceData        = event.PrintPlaceData
+	ModerationData        = event.ModerationData
+	UnrecognizedData      = event.UnrecognizedData
+)
+
+// NewEvent returns an Event of d's type with the given timestamp and
+// payload d, or the zero Event if d is nil. See event.New.
+func NewEvent(ts time.Time, d Data) Event {
+	return event.New(ts, d)
+}
+
+// SchemaVersion is the version of the JSON wire format of Event and
+// TypedEvent, emitted as "schema_version". See event.SchemaVersion.
+const SchemaVersion = event.SchemaVersion
+
+// JSONSchema returns a JSON Schema document describing the JSON form of
+// Event. See event.JSONSchema.
+func JSONSchema() []byte {
+	return event.JSONSchema()
+}
+
+// LogEntry is a generic VRChat log entry (timestamp, level, category, message).
+type LogEntry = event.LogEntry
+
+// LogLevel is the normalized log level of a LogEntry.
+type LogLevel = event.Level
+
+// Log level constants.
+const (
+	LevelDebug     = event.LevelDebug
+	LevelLog       = event.LevelLog
+	LevelWarning   = event.LevelWarning
+	LevelError     = event.LevelError
+	LevelException = event.LevelException
+)
+
+// InstanceInfo is the structured form of a VRChat instance ID.
+type InstanceInfo = event.InstanceInfo
+
+// AccessType describes who can join an instance.
+type AccessType = event.AccessType
+
+// Instance access type constants.
+const (
+	AccessPublic      = event.AccessPublic
+	AccessFriendsPlus = event.AccessFriendsPlus
+	AccessFriends     = event.AccessFriends
+	AccessInvite      = event.AccessInvite
+	AccessInvitePlus  = event.AccessInvitePlus
+	AccessGroup       = event.AccessGroup
+)
+
+// ParseInstanceID parses a VRChat instance ID (e.g. Event.InstanceID)
+// into an InstanceInfo. See event.ParseInstanceID for details.
+func ParseInstanceID(s string) (InstanceInfo, error) {
+	return event.ParseInstanceID(s)
+}
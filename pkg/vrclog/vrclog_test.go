@@ -5,12 +5,19 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/vrclog/vrclog-go/pkg/vrclog"
 )
 
+// data returns the payload of ev as a T, or the zero T if it is not one.
+func data[T vrclog.Data](ev vrclog.Event) T {
+	d, _ := ev.Data.(T)
+	return d
+}
+
 func TestParseLine(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -23,6 +30,17 @@ func TestParseLine(t *testing.T) {
 			input:    "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser",
 			wantType: vrclog.EventPlayerJoin,
 		},
+		{
+			name:     "world leave",
+			input:    "2024.01.15 23:59:59 Log        -  [Behaviour] OnLeftRoom",
+			wantType: vrclog.EventWorldLeave,
+		},
+		{
+			// Logged next to OnLeftRoom; one leave must yield one event
+			name:    "OnPlayerLeftRoom returns nil",
+			input:   "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerLeftRoom",
+			wantNil: true,
+		},
 		{
 			name:    "unrecognized line returns nil",
 			input:   "some random text",
@@ -110,8 +128,8 @@ func TestWatcher_ReceivesEvents(t *testing.T) {
 		if event.Type != vrclog.EventPlayerJoin {
 			t.Errorf("got type %v, want %v", event.Type, vrclog.EventPlayerJoin)
 		}
-		if event.PlayerName != "TestUser" {
-			t.Errorf("got player %q, want %q", event.PlayerName, "TestUser")
+		if data[vrclog.PlayerJoinData](event).PlayerName != "TestUser" {
+			t.Errorf("got player %q, want %q", data[vrclog.PlayerJoinData](event).PlayerName, "TestUser")
 		}
 	case err := <-errs:
 		t.Fatalf("unexpected error: %v", err)
@@ -373,6 +391,22 @@ func TestNewWatcherWithOptions_Validation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "zero FlushTimeout is valid",
+			opts: []vrclog.WatchOption{
+				vrclog.WithLogDir(dir),
+				vrclog.WithFlushTimeout(0),
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative FlushTimeout is invalid",
+			opts: []vrclog.WatchOption{
+				vrclog.WithLogDir(dir),
+				vrclog.WithFlushTimeout(-time.Millisecond),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -434,6 +468,229 @@ func TestWatcher_IncludeRawLine(t *testing.T) {
 	}
 }
 
+func TestWatcher_MultiLineEntry(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	f, err := os.Create(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	watcher, err := vrclog.NewWatcherWithOptions(
+		vrclog.WithLogDir(dir),
+		vrclog.WithIncludeRawLine(true),
+		vrclog.WithFlushTimeout(100*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, errs, err := watcher.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	// Give watcher time to start
+	time.Sleep(100 * time.Millisecond)
+
+	// No following header: the entry must be emitted by the flush timeout
+	f.WriteString("2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser\n")
+	f.WriteString("  continuation line\n")
+	f.Sync()
+
+	want := "2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser\n  continuation line"
+	select {
+	case event := <-events:
+		if event.RawLine != want {
+			t.Errorf("got RawLine %q, want %q", event.RawLine, want)
+		}
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for event")
+	}
+}
+
+func TestWatcher_ZeroFlushTimeout(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	f, err := os.Create(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	watcher, err := vrclog.NewWatcherWithOptions(
+		vrclog.WithLogDir(dir),
+		vrclog.WithFlushTimeout(0),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, errs, err := watcher.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	// Give watcher time to start
+	time.Sleep(100 * time.Millisecond)
+
+	start := time.Now()
+	f.WriteString("2024.01.15 23:59:59 Log        -  [Behaviour] OnPlayerJoined TestUser\n")
+	f.Sync()
+
+	select {
+	case event := <-events:
+		if event.Type != vrclog.EventPlayerJoin {
+			t.Errorf("got %s, want player_join", event.Type)
+		}
+		// Without a following line, only a flush can emit the entry
+		if elapsed := time.Since(start); elapsed >= vrclog.DefaultFlushTimeout {
+			t.Errorf("event took %v, want no flush delay", elapsed)
+		}
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for event")
+	}
+}
+
+func TestWatcher_AppStartFlushedWhenQuiet(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	f, err := os.Create(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	watcher, err := vrclog.NewWatcherWithOptions(
+		vrclog.WithLogDir(dir),
+		vrclog.WithFlushTimeout(100*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, errs, err := watcher.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	// Give watcher time to start
+	time.Sleep(100 * time.Millisecond)
+
+	// No following event: the merged app_start must be emitted once the log goes quiet
+	f.WriteString("2024.01.15 23:59:59 Log        -  VRChat Build: 2024.1.1p2-1409--Release\n")
+	f.WriteString("2024.01.15 23:59:59 Log        -  XR Device: None\n")
+	f.Sync()
+
+	select {
+	case event := <-events:
+		if d := data[vrclog.AppStartData](event); event.Type != vrclog.EventAppStart || d.BuildVersion != "2024.1.1p2-1409--Release" || d.VRMode != "desktop" {
+			t.Errorf("got %+v, want merged app_start", event)
+		}
+		if event.RawLine != "" {
+			t.Errorf("got RawLine %q without WithIncludeRawLine", event.RawLine)
+		}
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for event")
+	}
+}
+
+func TestWatcher_WorldJoinMerged(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "output_log_test.txt")
+
+	f, err := os.Create(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	watcher, err := vrclog.NewWatcherWithOptions(
+		vrclog.WithLogDir(dir),
+		vrclog.WithFlushTimeout(100*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, errs, err := watcher.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	// Give watcher time to start
+	time.Sleep(100 * time.Millisecond)
+
+	f.WriteString("2024.01.15 23:59:50 Log        -  [Behaviour] Joining wrld_12345678-1234-1234-1234-123456789abc:12345~region(jp)\n")
+	f.WriteString("2024.01.15 23:59:51 Log        -  [AssetBundleDownloadManager] [3] Starting download of World wrld_12345678-1234-1234-1234-123456789abc\n")
+	f.Sync()
+
+	// The download is delivered, but the quiet flush keeps the half held
+	select {
+	case event := <-events:
+		if event.Type != vrclog.EventAssetDownload {
+			t.Fatalf("got %+v, want asset_download", event)
+		}
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for event")
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("got %+v before the second half, want nothing", event)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	f.WriteString("2024.01.15 23:59:59 Log        -  [Behaviour] Entering Room: Test World\n")
+	f.Sync()
+
+	select {
+	case event := <-events:
+		if d := data[vrclog.WorldJoinData](event); event.Type != vrclog.EventWorldJoin || d.WorldName != "Test World" ||
+			d.WorldID != "wrld_12345678-1234-1234-1234-123456789abc" || d.InstanceID != "12345~region(jp)" {
+			t.Errorf("got %+v, want merged world_join", event)
+		}
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for event")
+	}
+
+	// Only one world_join is emitted
+	select {
+	case event := <-events:
+		t.Errorf("unexpected second event %+v", event)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
 func TestWatcher_ReplayFromStart(t *testing.T) {
 	dir := t.TempDir()
 	logFile := filepath.Join(dir, "output_log_test.txt")
@@ -464,8 +721,8 @@ func TestWatcher_ReplayFromStart(t *testing.T) {
 	// Should receive existing event
 	select {
 	case event := <-events:
-		if event.PlayerName != "ExistingUser" {
-			t.Errorf("got player %q, want %q", event.PlayerName, "ExistingUser")
+		if data[vrclog.PlayerJoinData](event).PlayerName != "ExistingUser" {
+			t.Errorf("got player %q, want %q", data[vrclog.PlayerJoinData](event).PlayerName, "ExistingUser")
 		}
 	case err := <-errs:
 		t.Fatalf("unexpected error: %v", err)
@@ -511,8 +768,8 @@ func TestWatcher_ReplayLastN(t *testing.T) {
 	for i, want := range expected {
 		select {
 		case event := <-events:
-			if event.PlayerName != want {
-				t.Errorf("event %d: got player %q, want %q", i, event.PlayerName, want)
+			if data[vrclog.PlayerJoinData](event).PlayerName != want {
+				t.Errorf("event %d: got player %q, want %q", i, data[vrclog.PlayerJoinData](event).PlayerName, want)
 			}
 		case err := <-errs:
 			t.Fatalf("unexpected error: %v", err)
@@ -522,6 +779,72 @@ func TestWatcher_ReplayLastN(t *testing.T) {
 	}
 }
 
+func TestWatcher_Source(t *testing.T) {
+	content := "2024.01.15 12:00:00 Log        -  [Behaviour] OnPlayerJoined User1\r\n" +
+		"2024.01.15 12:00:01 Log        -  Unrelated\r\n" +
+		"2024.01.15 12:00:02 Log        -  [Behaviour] OnPlayerJoined User2\r\n"
+	offset := int64(strings.LastIndex(content, "2024.01.15"))
+
+	tests := []struct {
+		name string
+		opt  vrclog.WatchOption
+		want []vrclog.Source // File is filled in below
+	}{
+		{
+			name: "from start",
+			opt:  vrclog.WithReplayFromStart(),
+			want: []vrclog.Source{{Line: 1, Offset: 0, Seq: 1}, {Line: 3, Offset: offset, Seq: 2}},
+		},
+		{
+			name: "last N",
+			opt:  vrclog.WithReplayLastN(1),
+			want: []vrclog.Source{{Line: 0, Offset: offset, Seq: 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			logFile := filepath.Join(dir, "output_log_test.txt")
+			if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			watcher, err := vrclog.NewWatcherWithOptions(
+				vrclog.WithLogDir(dir),
+				vrclog.WithSource(true),
+				vrclog.WithFlushTimeout(10*time.Millisecond),
+				tt.opt,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer watcher.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			events, errs, err := watcher.Watch(ctx)
+			if err != nil {
+				t.Fatalf("Watch() error = %v", err)
+			}
+
+			for i, want := range tt.want {
+				want.File = logFile
+				select {
+				case event := <-events:
+					if event.Source == nil || *event.Source != want {
+						t.Errorf("event %d: Source = %+v, want %+v", i, event.Source, want)
+					}
+				case err := <-errs:
+					t.Fatalf("unexpected error: %v", err)
+				case <-ctx.Done():
+					t.Fatalf("timeout waiting for event %d", i)
+				}
+			}
+		})
+	}
+}
+
 func TestWatcher_ReplaySinceTime(t *testing.T) {
 	dir := t.TempDir()
 	logFile := filepath.Join(dir, "output_log_test.txt")
@@ -561,8 +884,8 @@ func TestWatcher_ReplaySinceTime(t *testing.T) {
 	for i, want := range expected {
 		select {
 		case event := <-events:
-			if event.PlayerName != want {
-				t.Errorf("event %d: got player %q, want %q", i, event.PlayerName, want)
+			if data[vrclog.PlayerJoinData](event).PlayerName != want {
+				t.Errorf("event %d: got player %q, want %q", i, data[vrclog.PlayerJoinData](event).PlayerName, want)
 			}
 		case err := <-errs:
 			t.Fatalf("unexpected error: %v", err)
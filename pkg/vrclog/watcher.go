@@ -32,6 +32,11 @@ const (
 // This limits memory usage to roughly tens of MB for typical VRChat logs.
 const DefaultMaxReplayLastN = 10000
 
+// DefaultFlushTimeout is the default time the watcher waits for continuation
+// lines before emitting a buffered multi-line entry. It is also the latency
+// of live events; see WithFlushTimeout.
+const DefaultFlushTimeout = 250 * time.Millisecond
+
 // watcherErrBuffer is the buffer size for the error channel.
 // A small buffer prevents error loss during brief moments when the consumer
 // is busy processing events, while keeping memory usage minimal.
@@ -51,6 +56,12 @@ type Watcher struct {
 	logDir string
 	log    *slog.Logger
 
+	// Owned by the run goroutine
+	session session
+	file    string   // log file being read
+	start   linePos  // position of the entry pending in the assembler
+	seq     sequence // numbers events with a Source
+
 	mu       sync.Mutex
 	closed   bool
 	cancel   context.CancelFunc // cancel func to stop the goroutine
@@ -131,6 +142,7 @@ func (w *Watcher) run(ctx context.Context, eventCh chan<- Event, errCh chan<- er
 		return
 	}
 	w.log.Debug("found latest log file", "path", logFile)
+	w.file = logFile
 
 	// Configure tailer
 	cfg := tailer.DefaultConfig()
@@ -138,13 +150,24 @@ func (w *Watcher) run(ctx context.Context, eventCh chan<- Event, errCh chan<- er
 	// For ReplayLastN, we handle it specially below
 	cfg.FromStart = w.cfg.replay.Mode == ReplayFromStart || w.cfg.replay.Mode == ReplaySinceTime
 
+	// Group continuation lines (stack traces etc.) with their header.
+	// A pending entry is emitted when the next header arrives or after
+	// flushTimeout without new lines, or right away if flushTimeout is 0.
+	asm := &parser.Assembler{}
+	flushTimer := time.NewTimer(w.cfg.flushTimeout)
+	flushTimer.Stop()
+	defer flushTimer.Stop()
+
 	// Handle ReplayLastN: read last N lines first, then tail from end
 	if w.cfg.replay.Mode == ReplayLastN && w.cfg.replay.LastN > 0 {
 		w.log.Debug("replaying last N lines", "n", w.cfg.replay.LastN, "path", logFile)
-		if err := w.replayLastN(ctx, logFile, eventCh, errCh); err != nil {
+		if err := w.replayLastN(ctx, logFile, asm, eventCh, errCh); err != nil {
 			sendError(ctx, errCh, &WatchError{Op: WatchOpReplay, Path: logFile, Err: err})
 		}
 		cfg.FromStart = false // Continue from end after replay
+		if asm.Pending() {
+			w.flushIdle(ctx, asm, flushTimer, eventCh, errCh)
+		}
 	}
 
 	// Start tailer
@@ -171,7 +194,12 @@ func (w *Watcher) run(ctx context.Context, eventCh chan<- Event, errCh chan<- er
 			if !ok {
 				return
 			}
-			w.processLine(ctx, line, eventCh, errCh)
+			w.feedLine(ctx, asm, line, eventCh, errCh)
+			if asm.Pending() {
+				w.flushIdle(ctx, asm, flushTimer, eventCh, errCh)
+			}
+		case <-flushTimer.C:
+			w.flushQuiet(ctx, asm, eventCh, errCh)
 		case err, ok := <-t.Errors():
 			if !ok {
 				return
@@ -187,6 +215,10 @@ func (w *Watcher) run(ctx context.Context, eventCh chan<- Event, errCh chan<- er
 			if newFile != currentFile {
 				// New log file found, switch to it
 				w.log.Debug("log rotation detected", "from", currentFile, "to", newFile)
+				if entry, ok := asm.Flush(); ok {
+					w.processEntry(ctx, entry, w.start, eventCh, errCh)
+				}
+				w.flushSession(ctx, eventCh, true)
 				_ = t.Stop()
 				cfg := tailer.DefaultConfig()
 				cfg.FromStart = true // Read new file from start
@@ -197,35 +229,95 @@ func (w *Watcher) run(ctx context.Context, eventCh chan<- Event, errCh chan<- er
 				}
 				t = newTailer
 				currentFile = newFile
+				w.file = newFile
+				// A new log file means a new VRChat session
+				w.session = session{}
 			}
 		}
 	}
 }
 
-func (w *Watcher) processLine(ctx context.Context, line string, eventCh chan<- Event, errCh chan<- error) {
-	ev, err := parser.Parse(line)
+// flushIdle schedules the pending entry to be flushed after flushTimeout,
+// or flushes it right away if the timeout is 0.
+func (w *Watcher) flushIdle(ctx context.Context, asm *parser.Assembler, timer *time.Timer, eventCh chan<- Event, errCh chan<- error) {
+	if w.cfg.flushTimeout == 0 {
+		w.flushQuiet(ctx, asm, eventCh, errCh)
+		return
+	}
+	timer.Reset(w.cfg.flushTimeout)
+}
+
+// flushQuiet processes the pending entry and releases a held app_start
+// once the log has gone quiet: no more client information lines follow.
+func (w *Watcher) flushQuiet(ctx context.Context, asm *parser.Assembler, eventCh chan<- Event, errCh chan<- error) {
+	if entry, ok := asm.Flush(); ok {
+		w.processEntry(ctx, entry, w.start, eventCh, errCh)
+	}
+	w.flushSession(ctx, eventCh, false)
+}
+
+// feedLine adds a physical line to the assembler and processes the
+// previous entry if line completes it.
+func (w *Watcher) feedLine(ctx context.Context, asm *parser.Assembler, line tailer.Line, eventCh chan<- Event, errCh chan<- error) {
+	header := w.cfg.source && parser.IsEntryStart([]byte(line.Text))
+	if entry, ok := asm.Add(line.Text); ok {
+		w.processEntry(ctx, entry, w.start, eventCh, errCh)
+	}
+	if header {
+		w.start = linePos{line: line.Num, offset: line.Offset}
+	}
+}
+
+// processEntry parses a complete (possibly multi-line) entry starting at
+// pos and sends the resulting event, if any.
+func (w *Watcher) processEntry(ctx context.Context, entry string, pos linePos, eventCh chan<- Event, errCh chan<- error) {
+	if w.cfg.entryHandler != nil {
+		w.handleEntry(ctx, entry, errCh)
+	}
+
+	ev, err := w.cfg.parsers.parse(entry, w.cfg.location)
 	if err != nil {
-		sendError(ctx, errCh, &ParseError{Line: line, Err: err})
+		sendError(ctx, errCh, &ParseError{Line: entry, Err: err})
 		return
 	}
-	if ev == nil {
-		return // Not a recognized event
+	if ev == nil && w.cfg.unrecognized {
+		ev = parser.ParseUnrecognized(entry, w.cfg.location)
+	}
+	if ev != nil && w.cfg.source {
+		ev.Source = pos.source(w.file)
 	}
 
-	// Filter by replay time if needed (do this early before other processing)
+	// Track session state before filtering so skipped entries still count
+	for _, ev := range w.session.observe(entry, ev) {
+		w.sendEvent(ctx, ev, eventCh)
+	}
+}
+
+// flushSession sends the events held back by the session, if any; see
+// session.flush for final.
+func (w *Watcher) flushSession(ctx context.Context, eventCh chan<- Event, final bool) {
+	for _, ev := range w.session.flush(final) {
+		w.sendEvent(ctx, ev, eventCh)
+	}
+}
+
+// sendEvent filters ev and sends it to eventCh.
+func (w *Watcher) sendEvent(ctx context.Context, ev *Event, eventCh chan<- Event) {
+	// Filter by replay time if needed
 	if w.cfg.replay.Mode == ReplaySinceTime && ev.Timestamp.Before(w.cfg.replay.Since) {
 		return
 	}
 
-	// Apply event type filter (do this before copying RawLine for efficiency)
+	// Apply event type filter
 	if w.cfg.filter != nil && !w.cfg.filter.Allows(EventType(ev.Type)) {
 		return
 	}
 
-	// Include raw line if requested
-	if w.cfg.includeRawLine {
-		ev.RawLine = line
+	// The session always records the raw line; drop it unless requested
+	if !w.cfg.includeRawLine {
+		ev.RawLine = ""
 	}
+	w.seq.stamp(ev)
 
 	// Send event
 	select {
@@ -234,8 +326,30 @@ func (w *Watcher) processLine(ctx context.Context, line string, eventCh chan<- E
 	}
 }
 
+// handleEntry parses entry into a LogEntry and passes it to the entry handler.
+func (w *Watcher) handleEntry(ctx context.Context, entry string, errCh chan<- error) {
+	le, err := parser.ParseEntryInLocation(entry, w.cfg.location)
+	if err != nil {
+		sendError(ctx, errCh, &ParseError{Line: entry, Err: err})
+		return
+	}
+	if le == nil {
+		return
+	}
+
+	if w.cfg.replay.Mode == ReplaySinceTime && le.Timestamp.Before(w.cfg.replay.Since) {
+		return
+	}
+	if !w.cfg.entryFilter.Allows(le) {
+		return
+	}
+
+	w.cfg.entryHandler(*le)
+}
+
 // replayLastN reads and processes the last N lines from the log file.
-func (w *Watcher) replayLastN(ctx context.Context, logFile string, eventCh chan<- Event, errCh chan<- error) error {
+// The last entry may remain pending in asm until the tail loop flushes it.
+func (w *Watcher) replayLastN(ctx context.Context, logFile string, asm *parser.Assembler, eventCh chan<- Event, errCh chan<- error) error {
 	lines, err := readLastNLines(logFile, w.cfg.replay.LastN)
 	if err != nil {
 		return err
@@ -246,15 +360,16 @@ func (w *Watcher) replayLastN(ctx context.Context, logFile string, eventCh chan<
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			w.processLine(ctx, line, eventCh, errCh)
+			w.feedLine(ctx, asm, line, eventCh, errCh)
 		}
 	}
 	return nil
 }
 
 // readLastNLines reads the last N lines from a file.
-// Returns lines in order (oldest first).
-func readLastNLines(filepath string, n int) ([]string, error) {
+// Returns lines in order (oldest first), with their offsets but without
+// line numbers.
+func readLastNLines(filepath string, n int) ([]tailer.Line, error) {
 	file, err := os.Open(filepath)
 	if err != nil {
 		return nil, err
@@ -274,7 +389,7 @@ func readLastNLines(filepath string, n int) ([]string, error) {
 
 	// Read from end in chunks
 	const chunkSize = 4096
-	var lines []string
+	var lines []tailer.Line
 	var buffer []byte
 	offset := fileSize
 
@@ -297,21 +412,22 @@ func readLastNLines(filepath string, n int) ([]string, error) {
 		buffer = append(chunk, buffer...)
 
 		// Extract complete lines from buffer
-		lines = extractLines(buffer, n)
+		lines = extractLines(buffer, offset, n)
 	}
 
 	// If we have the entire file in buffer, extract all lines
 	if offset == 0 && len(lines) < n {
-		lines = extractLines(buffer, n)
+		lines = extractLines(buffer, offset, n)
 	}
 
 	return lines, nil
 }
 
-// extractLines extracts up to n lines from buffer, keeping only the last n.
+// extractLines extracts up to n lines from buffer, which starts at byte
+// offset base in the file, keeping only the last n.
 // Returns lines in order (oldest first).
-func extractLines(buffer []byte, n int) []string {
-	var lines []string
+func extractLines(buffer []byte, base int64, n int) []tailer.Line {
+	var lines []tailer.Line
 	start := 0
 
 	for i := 0; i < len(buffer); i++ {
@@ -322,7 +438,7 @@ func extractLines(buffer []byte, n int) []string {
 				line = line[:len(line)-1]
 			}
 			if line != "" {
-				lines = append(lines, line)
+				lines = append(lines, tailer.Line{Text: line, Offset: base + int64(start)})
 			}
 			start = i + 1
 		}
@@ -335,7 +451,7 @@ func extractLines(buffer []byte, n int) []string {
 			line = line[:len(line)-1]
 		}
 		if line != "" {
-			lines = append(lines, line)
+			lines = append(lines, tailer.Line{Text: line, Offset: base + int64(start)})
 		}
 	}
 